@@ -40,6 +40,8 @@ flags:`)
 		paramsStr          = flags.String("params", "", "list of parameters in the format: \"key:value,key:value\"")
 		ignoreList         = flags.String("ignore", "", "comma separated list of interfaces to ignore")
 		suppressErrorField = flags.Bool("suppressErrorField", false, "suppress error field in response")
+		errorObject        = flags.String("errorObject", "", "object to use for the error field in responses (default: string)")
+		acronyms           = flags.String("acronyms", "", "comma separated list of extra acronyms to keep together, like OAuth")
 	)
 	if err := flags.Parse(args[1:]); err != nil {
 		return err
@@ -55,10 +57,14 @@ flags:`)
 	}
 	p := parser.New(flags.Args()...)
 	p.SuppressErrorField = *suppressErrorField
+	p.ErrorObject = *errorObject
 	ignoreItems := strings.Split(*ignoreList, ",")
 	if ignoreItems[0] != "" {
 		p.ExcludeInterfaces = ignoreItems
 	}
+	if *acronyms != "" {
+		p.Acronyms = strings.Split(*acronyms, ",")
+	}
 	p.Verbose = *v
 	if p.Verbose {
 		fmt.Println("oto - github.com/pacedotdev/oto", Version)
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// AsyncAPI generates an AsyncAPI 2.x document (as JSON) describing
+// the streaming methods, with a channel for each.
+// Non-streaming methods are not included.
+// Operation ids come from OperationID, so they match OpenAPI.
+func (d *Definition) AsyncAPI() ([]byte, error) {
+	doc := asyncAPIDocument{
+		AsyncAPI: "2.6.0",
+		Info: asyncAPIInfo{
+			Title:   d.PackageName,
+			Version: "0.1.0",
+		},
+		Channels: make(map[string]asyncAPIChannel),
+		Components: asyncAPIComponents{
+			Schemas: make(map[string]*jsonSchema),
+		},
+	}
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if !method.Streaming {
+				continue
+			}
+			name := service.Name + "." + method.Name
+			operationID, err := d.OperationID(service, method)
+			if err != nil {
+				return nil, err
+			}
+			messageName := method.OutputObject.CleanObjectName
+			doc.Channels[name] = asyncAPIChannel{
+				Description: method.Comment,
+				Subscribe: asyncAPIOperation{
+					OperationID: operationID,
+					Summary:     method.Comment,
+					Message: asyncAPIMessage{
+						Name:    messageName,
+						Payload: &jsonSchema{Ref: asyncAPISchemaRefPrefix + messageName},
+					},
+				},
+			}
+			objects, err := d.ObjectWithDependencies(messageName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s: output object", name)
+			}
+			for _, object := range objects {
+				doc.Components.Schemas[object.Name] = d.jsonSchemaForObject(object, "asyncapi", asyncAPISchemaRefPrefix)
+			}
+		}
+	}
+	b, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal")
+	}
+	return b, nil
+}
+
+// asyncAPISchemaRefPrefix is the prefix of references to schemas in
+// AsyncAPI documents.
+const asyncAPISchemaRefPrefix = "#/components/schemas/"
+
+type asyncAPIDocument struct {
+	AsyncAPI   string                     `json:"asyncapi"`
+	Info       asyncAPIInfo               `json:"info"`
+	Channels   map[string]asyncAPIChannel `json:"channels"`
+	Components asyncAPIComponents         `json:"components"`
+}
+
+type asyncAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type asyncAPIChannel struct {
+	Description string            `json:"description,omitempty"`
+	Subscribe   asyncAPIOperation `json:"subscribe"`
+}
+
+type asyncAPIOperation struct {
+	OperationID string          `json:"operationId"`
+	Summary     string          `json:"summary,omitempty"`
+	Message     asyncAPIMessage `json:"message"`
+}
+
+type asyncAPIMessage struct {
+	Name    string      `json:"name"`
+	Payload *jsonSchema `json:"payload"`
+}
+
+type asyncAPIComponents struct {
+	Schemas map[string]*jsonSchema `json:"schemas"`
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestAsyncAPI(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/streaming"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	is.Equal(def.Services[0].Methods[0].Name, "Quote")
+	is.Equal(def.Services[0].Methods[0].Streaming, false)
+	is.Equal(def.Services[0].Methods[1].Name, "Watch")
+	is.Equal(def.Services[0].Methods[1].Streaming, true)
+	is.Equal(def.Services[0].Methods[1].OutputObject.CleanObjectName, "PriceChange")
+
+	b, err := def.AsyncAPI()
+	is.NoErr(err)
+	var doc struct {
+		AsyncAPI string `json:"asyncapi"`
+		Channels map[string]struct {
+			Subscribe struct {
+				OperationID string `json:"operationId"`
+				Message     struct {
+					Name    string `json:"name"`
+					Payload struct {
+						Ref string `json:"$ref"`
+					} `json:"payload"`
+				} `json:"message"`
+			} `json:"subscribe"`
+		} `json:"channels"`
+		Components struct {
+			Schemas map[string]*jsonSchema `json:"schemas"`
+		} `json:"components"`
+	}
+	is.NoErr(json.Unmarshal(b, &doc))
+	is.Equal(doc.AsyncAPI, "2.6.0")
+	is.Equal(len(doc.Channels), 1) // only streaming methods
+	watch, ok := doc.Channels["PriceService.Watch"]
+	is.True(ok)
+	is.Equal(watch.Subscribe.OperationID, "priceServiceWatch")
+	is.Equal(watch.Subscribe.Message.Name, "PriceChange")
+	is.Equal(watch.Subscribe.Message.Payload.Ref, "#/components/schemas/PriceChange")
+
+	priceChange, ok := doc.Components.Schemas["PriceChange"]
+	is.True(ok)
+	is.Equal(priceChange.Properties["price"].Type, jsonSchemaType{"number"})
+	is.Equal(priceChange.Properties["trades"].Type, jsonSchemaType{"array"})
+	is.Equal(priceChange.Properties["trades"].Items.Ref, "#/components/schemas/Trade")
+	is.Equal(priceChange.Properties["volumes"].AdditionalProperties.Type, jsonSchemaType{"array"})
+	is.Equal(priceChange.Properties["volumes"].AdditionalProperties.Items.Type, jsonSchemaType{"integer"}) // map[string][]int
+	_, ok = doc.Components.Schemas["Trade"]
+	is.True(ok) // dependency
+	_, ok = doc.Components.Schemas["QuoteResponse"]
+	is.True(!ok) // not streamed
+}
+
+func TestAsyncAPIOperationID(t *testing.T) {
+	is := is.New(t)
+	def := &Definition{
+		Services: []Service{
+			{
+				Name: "PriceService",
+				Methods: []Method{
+					{
+						Name:         "Watch",
+						Streaming:    true,
+						Metadata:     map[string]interface{}{"operation_id": "watchPrices"},
+						InputObject:  FieldType{CleanObjectName: "WatchRequest"},
+						OutputObject: FieldType{CleanObjectName: "PriceChange"},
+					},
+				},
+			},
+		},
+		Objects: []Object{{Name: "WatchRequest"}, {Name: "PriceChange"}},
+	}
+	b, err := def.AsyncAPI()
+	is.NoErr(err)
+	var doc struct {
+		Channels map[string]struct {
+			Subscribe struct {
+				OperationID string `json:"operationId"`
+			} `json:"subscribe"`
+		} `json:"channels"`
+	}
+	is.NoErr(json.Unmarshal(b, &doc))
+	is.Equal(doc.Channels["PriceService.Watch"].Subscribe.OperationID, "watchPrices") // operation_id metadata
+
+	def.Services[0].Methods[0].Metadata["operation_id"] = 1
+	_, err = def.AsyncAPI()
+	is.True(err != nil) // not a string
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CurlExample generates a cURL command that calls the method, with
+// an example of the input object as the body.
+// Oto methods are always POST requests, and OutputOnly fields are
+// left out of the body.
+// baseURL is where the services are served, like
+// https://example.com/oto.
+func (d *Definition) CurlExample(service, method, baseURL string) (string, error) {
+	m, err := d.method(service, method)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s.%s", service, method)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "curl -X POST %s/%s.%s \\\n", strings.TrimSuffix(baseURL, "/"), service, method)
+	fmt.Fprintf(&buf, "\t-H 'Content-Type: %s' \\\n", m.RequestContentType)
+	fmt.Fprintf(&buf, "\t-H 'Accept: %s' \\\n", m.ResponseContentType)
+	if m.BinaryRequest {
+		fmt.Fprint(&buf, "\t--data-binary @file")
+		return buf.String(), nil
+	}
+	object, err := d.Object(m.InputObject.CleanObjectName)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s.%s", service, method)
+	}
+	input := *object
+	input.Fields = input.InputFields()
+	example, err := d.Example(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s.%s", service, method)
+	}
+	body, err := json.Marshal(example)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s.%s", service, method)
+	}
+	// escape single quotes for the shell
+	fmt.Fprintf(&buf, "\t-d '%s'", strings.ReplaceAll(string(body), "'", `'\''`))
+	return buf.String(), nil
+}
+
+// method finds the method in the service.
+// Returns ErrNotFound if either cannot be found.
+func (d *Definition) method(serviceName, methodName string) (Method, error) {
+	for _, service := range d.Services {
+		if service.Name != serviceName {
+			continue
+		}
+		for _, method := range service.Methods {
+			if method.Name == methodName {
+				return method, nil
+			}
+		}
+	}
+	return Method{}, ErrNotFound
+}
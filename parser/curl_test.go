@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCurlExample(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	curl, err := def.CurlExample("GreeterService", "Greet", "https://example.com/oto/")
+	is.NoErr(err)
+	is.Equal(curl, `curl -X POST https://example.com/oto/GreeterService.Greet \
+	-H 'Content-Type: application/json' \
+	-H 'Accept: application/json' \
+	-d '{"names":["Mat","David"]}'`)
+
+	_, err = def.CurlExample("GreeterService", "Missing", "https://example.com/oto")
+	is.True(err != nil)
+}
+
+func TestCurlExamplePostsInputFields(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	errorField := greetResponse.Fields[len(greetResponse.Fields)-1]
+	is.Equal(errorField.OutputOnly, true)
+	for i := range def.Objects {
+		if def.Objects[i].Name == "GreetRequest" {
+			def.Objects[i].Fields = append(def.Objects[i].Fields, errorField)
+		}
+	}
+	def.Services[0].Methods[0].Metadata["http_method"] = "GET"
+
+	curl, err := def.CurlExample("GreeterService", "Greet", "https://example.com/oto")
+	is.NoErr(err)
+	is.Equal(curl, `curl -X POST https://example.com/oto/GreeterService.Greet \
+	-H 'Content-Type: application/json' \
+	-H 'Accept: application/json' \
+	-d '{"names":["Mat","David"]}'`) // always POST, and no error field
+}
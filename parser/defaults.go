@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"bufio"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// constDefaultRegex matches default metadata that names a constant,
+// like default: StatusActive or default: status.Active.
+var constDefaultRegex = regexp.MustCompile(`^default:\s*([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)?)$`)
+
+// constDefault resolves default metadata in the comment that refers
+// to a constant, by name, to the literal value of the constant.
+// Constants are looked up in pkg, or in one of its imports if the
+// name is qualified.
+// Integers and floats are float64, as if they were parsed from JSON.
+func constDefault(pkg *packages.Package, comment string) (interface{}, bool) {
+	s := bufio.NewScanner(strings.NewReader(comment))
+	for s.Scan() {
+		match := constDefaultRegex.FindStringSubmatch(strings.TrimSpace(s.Text()))
+		if match == nil {
+			continue
+		}
+		c, ok := lookupConst(pkg.Types, match[1])
+		if !ok {
+			return nil, false
+		}
+		return constLiteral(c.Val())
+	}
+	return nil, false
+}
+
+// lookupConst finds the constant called name in pkg, where name may
+// be qualified with the name of a package pkg imports.
+func lookupConst(pkg *types.Package, name string) (*types.Const, bool) {
+	if pkg == nil {
+		return nil, false
+	}
+	scope := pkg.Scope()
+	if qualifier := strings.SplitN(name, ".", 2); len(qualifier) == 2 {
+		scope = nil
+		for _, imported := range pkg.Imports() {
+			if imported.Name() == qualifier[0] {
+				scope = imported.Scope()
+				break
+			}
+		}
+		if scope == nil {
+			return nil, false
+		}
+		name = qualifier[1]
+	}
+	c, ok := scope.Lookup(name).(*types.Const)
+	return c, ok
+}
+
+// constLiteral gets the Go value for a constant.
+func constLiteral(val constant.Value) (interface{}, bool) {
+	switch val.Kind() {
+	case constant.String:
+		return constant.StringVal(val), true
+	case constant.Bool:
+		return constant.BoolVal(val), true
+	case constant.Int, constant.Float:
+		f, _ := constant.Float64Val(val)
+		return f, true
+	}
+	return nil, false
+}
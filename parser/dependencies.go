@@ -0,0 +1,82 @@
+package parser
+
+import "fmt"
+
+// ObjectWithDependencies gets the named object along with every object
+// it depends on through its fields, directly or indirectly.
+// Useful for generating code for a single object on demand.
+// Objects are returned in the order they appear in Definition.Objects.
+// Returns ErrNotFound if there is no object called name.
+func (d *Definition) ObjectWithDependencies(name string) ([]Object, error) {
+	object, err := d.Object(name)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	if err := d.objectDependencies(object, seen); err != nil {
+		return nil, err
+	}
+	objects := make([]Object, 0, len(seen))
+	for _, object := range d.Objects {
+		if seen[object.Name] {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// UnreferencedObjects gets the objects that are not used by any
+// method, either as an input or output object, or through their
+// fields, directly or indirectly.
+// Useful for finding structs that can be cleaned up.
+// Objects are returned in the order they appear in Definition.Objects.
+func (d *Definition) UnreferencedObjects() []Object {
+	seen := map[string]bool{}
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			for _, name := range []string{method.InputObject.CleanObjectName, method.OutputObject.CleanObjectName} {
+				if seen[name] {
+					continue
+				}
+				object, err := d.Object(name)
+				if err != nil {
+					continue
+				}
+				// missing dependencies are not objects, so cannot
+				// be unreferenced
+				_ = d.objectDependencies(object, seen)
+			}
+		}
+	}
+	var objects []Object
+	for _, object := range d.Objects {
+		if !seen[object.Name] {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+func (d *Definition) objectDependencies(object *Object, seen map[string]bool) error {
+	seen[object.Name] = true
+	for _, field := range object.Fields {
+		var dependency string
+		switch {
+		case field.Type.IsObject:
+			dependency = field.Type.CleanObjectName
+		case field.Type.IsMap() && field.Type.Map.ElementIsObject:
+			dependency = field.Type.Map.CleanElementType
+		}
+		if dependency == "" || seen[dependency] {
+			continue
+		}
+		fieldObject, err := d.Object(dependency)
+		if err != nil {
+			return fmt.Errorf("Object(%q): %w", dependency, err)
+		}
+		if err := d.objectDependencies(fieldObject, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestObjectWithDependencies(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	objects, err := def.ObjectWithDependencies("GreetResponse")
+	is.NoErr(err)
+	is.Equal(len(objects), 2)
+	is.Equal(objects[0].Name, "GreetResponse")
+	is.Equal(objects[1].Name, "Greeting")
+
+	objects, err = def.ObjectWithDependencies("GreetRequest")
+	is.NoErr(err)
+	is.Equal(len(objects), 1) // no dependencies
+	is.Equal(objects[0].Name, "GreetRequest")
+
+	_, err = def.ObjectWithDependencies("Unknown")
+	is.Equal(err, ErrNotFound)
+}
+
+func TestObjectWithDependenciesMaps(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/maps"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	objects, err := def.ObjectWithDependencies("GetStatsResponse")
+	is.NoErr(err)
+	is.Equal(len(objects), 2)
+	is.Equal(objects[0].Name, "GetStatsResponse")
+	is.Equal(objects[1].Name, "Greeting") // map element
+}
+
+func TestUnreferencedObjects(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/unreferenced"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	objects := def.UnreferencedObjects()
+	is.Equal(len(objects), 1)
+	is.Equal(objects[0].Name, "Farewell")
+}
@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Enum describes a named string or integer type with a set of
+// constant values, like:
+//
+//	type Status string
+//	const StatusActive Status = "active"
+type Enum struct {
+	Name string `json:"name"`
+	// BaseType is the underlying Go type, like string or int.
+	BaseType string `json:"baseType"`
+	Comment  string `json:"comment"`
+	// Metadata are typed key/value pairs extracted from the
+	// comments.
+	Metadata map[string]interface{} `json:"metadata"`
+	Values   []EnumValue            `json:"values"`
+}
+
+// EnumValue is one of the values of an Enum.
+type EnumValue struct {
+	// Name is the name of the constant, like StatusActive.
+	Name string `json:"name"`
+	// ShortName is the Name without the enum type name prefix, like
+	// Active. Same as Name if it does not start with the type name.
+	ShortName string `json:"shortName"`
+	// Value is the value of the constant, a string or an int.
+	Value   interface{} `json:"value"`
+	Comment string      `json:"comment"`
+}
+
+// Enum looks up an enum by name. Returns ErrNotFound error
+// if it cannot find it.
+func (d *Definition) Enum(name string) (*Enum, error) {
+	for i := range d.Enums {
+		enum := &d.Enums[i]
+		if enum.Name == name {
+			return enum, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// parseEnum adds named to the Definition as an Enum if there are
+// constants of that type in the package that defines it, which may
+// be imported.
+// Returns false if named is not an enum.
+func (p *Parser) parseEnum(pkg *packages.Package, named *types.Named, basic *types.Basic) (bool, error) {
+	if basic.Info()&(types.IsString|types.IsInteger) == 0 {
+		return false, nil
+	}
+	typeName := named.Obj()
+	if typeName.Pkg() == nil {
+		return false, nil
+	}
+	if _, err := p.def.Enum(typeName.Name()); err == nil {
+		return true, nil // already parsed
+	}
+	scope := typeName.Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	if len(consts) == 0 {
+		return false, nil
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+	comments := enumComments(findPackage(pkg, typeName.Pkg().Path()), typeName.Name())
+	enum := Enum{
+		Name:     typeName.Name(),
+		BaseType: basic.Name(),
+	}
+	var err error
+	enum.Metadata, enum.Comment, err = p.extractCommentMetadata(comments[typeName.Name()])
+	if err != nil {
+		return false, p.wrapErr(err, pkg, typeName.Pos())
+	}
+	for _, c := range consts {
+		value := EnumValue{
+			Name:      c.Name(),
+			ShortName: enumValueShortName(typeName.Name(), c.Name()),
+			Comment:   cleanComment(comments[c.Name()]),
+		}
+		switch c.Val().Kind() {
+		case constant.String:
+			value.Value = constant.StringVal(c.Val())
+		case constant.Int:
+			n, _ := constant.Int64Val(c.Val())
+			value.Value = int(n)
+		}
+		enum.Values = append(enum.Values, value)
+	}
+	p.def.Enums = append(p.def.Enums, enum)
+	return true, nil
+}
+
+// enumValueShortName gets the name of the constant without the
+// enumName prefix. StatusActive becomes Active.
+func enumValueShortName(enumName, name string) string {
+	shortName := strings.TrimPrefix(name, enumName)
+	if shortName == "" || shortName == name {
+		return name
+	}
+	return shortName
+}
+
+// findPackage finds the package with the import path among pkg and
+// its imports.
+// Returns nil if it cannot be found.
+func findPackage(pkg *packages.Package, path string) *packages.Package {
+	seen := make(map[*packages.Package]bool)
+	queue := []*packages.Package{pkg}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil || seen[next] {
+			continue
+		}
+		seen[next] = true
+		if next.PkgPath == path {
+			return next
+		}
+		for _, imported := range next.Imports {
+			queue = append(queue, imported)
+		}
+	}
+	return nil
+}
+
+// enumComments gets the comments for the type called typeName and
+// each constant in pkg, keyed by name.
+// Comments after constants on the same line are used if there is no
+// comment above them.
+func enumComments(pkg *packages.Package, typeName string) map[string]string {
+	comments := make(map[string]string)
+	if pkg == nil {
+		return comments
+	}
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				group := specDoc(genDecl, spec)
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if genDecl.Tok == token.TYPE && spec.Name.Name == typeName {
+						comments[typeName] = group.Text()
+					}
+				case *ast.ValueSpec:
+					if genDecl.Tok != token.CONST {
+						continue
+					}
+					if group == nil {
+						group = spec.Comment
+					}
+					for _, name := range spec.Names {
+						comments[name.Name] = group.Text()
+					}
+				}
+			}
+		}
+	}
+	return comments
+}
+
+// specDoc gets the doc comment for spec, which is on the declaration
+// itself when it is the only spec.
+func specDoc(genDecl *ast.GenDecl, spec ast.Spec) *ast.CommentGroup {
+	var group *ast.CommentGroup
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		group = spec.Doc
+	case *ast.ValueSpec:
+		group = spec.Doc
+	}
+	if group == nil && len(genDecl.Specs) == 1 {
+		group = genDecl.Doc
+	}
+	return group
+}
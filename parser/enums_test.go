@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseEnums(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/enums"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	is.Equal(len(def.Enums), 4)
+
+	status, err := def.Enum("Status") // defined in an imported package
+	is.NoErr(err)
+	is.Equal(status.BaseType, "string")
+	is.Equal(status.Comment, "Status is the state of an item.")
+	is.Equal(len(status.Values), 2)
+	is.Equal(status.Values[0].Name, "StatusActive")
+	is.Equal(status.Values[0].ShortName, "Active")
+	is.Equal(status.Values[0].Value, "active")
+	is.Equal(status.Values[0].Comment, "StatusActive is for items in use.")
+	is.Equal(status.Values[1].Name, "StatusArchived")
+	is.Equal(status.Values[1].ShortName, "Archived")
+	is.Equal(status.Values[1].Value, "archived")
+
+	priority, err := def.Enum("Priority")
+	is.NoErr(err)
+	is.Equal(priority.BaseType, "int")
+	is.Equal(len(priority.Values), 2)
+	is.Equal(priority.Values[0].Name, "PriorityLow")
+	is.Equal(priority.Values[0].ShortName, "Low")
+	is.Equal(priority.Values[0].Value, 1)
+	is.Equal(priority.Values[0].Comment, "PriorityLow can wait.")
+	is.Equal(priority.Values[1].Value, 2)
+
+	color, err := def.Enum("Color")
+	is.NoErr(err)
+	is.Equal(len(color.Values), 2)
+	is.Equal(color.Values[0].Name, "ColorRed")
+	is.Equal(color.Values[0].ShortName, "Red")
+	is.Equal(color.Values[0].Value, "red")
+	is.Equal(color.Values[1].Name, "Green")
+	is.Equal(color.Values[1].ShortName, "Green") // no prefix to strip
+	is.Equal(color.Values[1].Value, "green")
+
+	_, err = def.Enum("Label")
+	is.Equal(err, ErrNotFound) // no constants
+
+	updateTaskRequest, err := def.Object("UpdateTaskRequest")
+	is.NoErr(err)
+	statusField := updateTaskRequest.Fields[0]
+	is.Equal(statusField.Type.IsEnum, true)
+	is.Equal(statusField.Type.CleanObjectName, "Status")
+	is.Equal(statusField.Type.TSType, "string")
+	priorityField := updateTaskRequest.Fields[1]
+	is.Equal(priorityField.Type.IsEnum, true)
+	is.Equal(priorityField.Type.JSType, "number")
+	is.Equal(updateTaskRequest.Fields[2].Type.IsEnum, false)
+
+	size, err := def.Enum("Size") // only used as map values
+	is.NoErr(err)
+	is.Equal(size.BaseType, "int")
+	is.Equal(len(size.Values), 2)
+	is.Equal(size.Values[0].Name, "SizeSmall")
+	is.Equal(size.Values[0].Value, 0) // iota
+	is.Equal(size.Values[1].Name, "SizeLarge")
+	is.Equal(size.Values[1].Value, 1)
+	is.Equal(size.Values[1].Comment, "SizeLarge takes a day.")
+	estimatesField := updateTaskRequest.Fields[4]
+	is.Equal(estimatesField.Type.IsEnum, false)
+	is.Equal(estimatesField.Type.Map.ElementIsEnum, true)
+	is.Equal(estimatesField.Type.Map.CleanElementType, "Size")
+	is.Equal(estimatesField.Type.Map.ElementTypeTS, "number[]")
+	is.Equal(estimatesField.Type.TSType, "Record<string, number[]>")
+	is.Equal(estimatesField.Type.KotlinType, "Map<String, List<Long>>")
+}
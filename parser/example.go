@@ -1,20 +1,50 @@
 package parser
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
 
 // Example generates an object that is a realistic example
 // of this object.
 // Examples are read from the docs.
 // This is experimental.
+//
+// Objects with discriminator metadata naming one of their fields
+// produce an example for a single variant: fields with variant
+// metadata are only included if it matches the example value of
+// the discriminator field.
 func (d *Definition) Example(o Object) (map[string]interface{}, error) {
+	return d.exampleIn(o, "")
+}
+
+// exampleIn generates an example of the object like Example, leaving
+// out fields (including those of nested objects) that are excluded
+// from the target generator.
+// An empty target keeps every field.
+func (d *Definition) exampleIn(o Object, target string) (map[string]interface{}, error) {
+	discriminator, hasDiscriminator, err := exampleDiscriminator(o)
+	if err != nil {
+		return nil, err
+	}
 	obj := make(map[string]interface{})
 	for _, field := range o.Fields {
+		if hasDiscriminator && !fieldInVariant(field, discriminator) {
+			continue
+		}
+		if target != "" && field.IsExcludedIn(target) {
+			continue
+		}
 		if field.Type.IsObject {
 			subobj, err := d.Object(field.Type.CleanObjectName)
 			if err != nil {
 				return nil, fmt.Errorf("Object(%q): %w", field.Type.CleanObjectName, err)
 			}
-			example, err := d.ExampleP(subobj)
+			example, err := d.exampleIn(*subobj, target)
 			if err != nil {
 				return nil, err
 			}
@@ -34,3 +64,74 @@ func (d *Definition) Example(o Object) (map[string]interface{}, error) {
 func (d *Definition) ExampleP(o *Object) (map[string]interface{}, error) {
 	return d.Example(*o)
 }
+
+// exampleDiscriminator gets the value of the field named by the
+// discriminator metadata of o, which selects the variant used
+// in examples.
+// If the discriminator field has no example, the first of its
+// options is used.
+func exampleDiscriminator(o Object) (string, bool, error) {
+	name, ok := o.Metadata["discriminator"]
+	if !ok {
+		return "", false, nil
+	}
+	fieldName, ok := name.(string)
+	if !ok {
+		return "", false, fmt.Errorf("%s: discriminator: expected string, got %T", o.Name, name)
+	}
+	for _, field := range o.Fields {
+		if field.Name != fieldName && field.NameLowerCamel != fieldName {
+			continue
+		}
+		if field.Example != nil {
+			return fmt.Sprintf("%v", field.Example), true, nil
+		}
+		if options, ok := field.Metadata["options"].([]interface{}); ok && len(options) > 0 {
+			return fmt.Sprintf("%v", options[0]), true, nil
+		}
+		return "", false, fmt.Errorf("%s: discriminator field %s needs an example or options", o.Name, fieldName)
+	}
+	return "", false, fmt.Errorf("%s: discriminator field %s not found", o.Name, fieldName)
+}
+
+// fieldInVariant gets whether the field should be included in the
+// example for the variant.
+// Fields without variant metadata are in every variant, otherwise
+// variant may be a string or a list of strings.
+func fieldInVariant(field Field, variant string) bool {
+	switch v := field.Metadata["variant"].(type) {
+	case nil:
+		return true
+	case string:
+		return v == variant
+	case []interface{}:
+		for i := range v {
+			if fmt.Sprintf("%v", v[i]) == variant {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readExampleFile reads the JSON example file named by the key
+// metadata, relative to ExampleDir.
+// Returns nil if there is no such metadata, or an error if the file
+// does not contain valid JSON.
+func (p *Parser) readExampleFile(metadata map[string]interface{}, key string) (json.RawMessage, error) {
+	path, err := metadataString(metadata, key, "")
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(filepath.Join(p.ExampleDir, path))
+	if err != nil {
+		return nil, errors.Wrap(err, key)
+	}
+	if !json.Valid(b) {
+		return nil, errors.Errorf("%s: %s: invalid JSON", key, path)
+	}
+	return json.RawMessage(b), nil
+}
@@ -113,3 +113,65 @@ func TestObjectExample(t *testing.T) {
 	is.Equal(exampleJSON["tags"].([]interface{})[0], "security")
 
 }
+
+func TestObjectExampleDiscriminator(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/discriminators"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	message, err := def.Object("Message")
+	is.NoErr(err)
+	example, err := def.Example(*message)
+	is.NoErr(err)
+	is.Equal(example["type"], "text")
+	is.Equal(example["sender"], "Mat")
+	is.Equal(example["text"], "Hello")
+	is.Equal(example["caption"], "Greetings")
+	_, hasImageURL := example["imageURL"]
+	is.True(!hasImageURL) // imageURL is only in the image variant
+
+	message.Fields[0].Example = "image"
+	example, err = def.Example(*message)
+	is.NoErr(err)
+	is.Equal(example["imageURL"], "https://example.com/image.png")
+	is.Equal(example["caption"], "Greetings")
+	_, hasText := example["text"]
+	is.True(!hasText) // text is only in the text variant
+
+	message.Fields[0].Example = nil
+	example, err = def.Example(*message)
+	is.NoErr(err)
+	is.Equal(example["text"], "Hello") // falls back to the first option
+
+	message.Metadata["discriminator"] = "Missing"
+	_, err = def.Example(*message)
+	is.True(err != nil)
+}
+
+func TestParseExampleFiles(t *testing.T) {
+	is := is.New(t)
+	parser := New("./testdata/examplefiles")
+	parser.Verbose = testing.Verbose()
+	parser.ExampleDir = "./testdata/examplefiles"
+	def, err := parser.Parse()
+	is.NoErr(err)
+	methods := def.Services[0].Methods
+	is.Equal(methods[0].Name, "Greet")
+	is.Equal(string(methods[0].InputExample), "{\n\t\"names\": [\"Mat\", \"David\"]\n}\n")
+	is.Equal(string(methods[0].OutputExample), "{\n\t\"greeting\": \"Hello Mat and David\"\n}\n")
+	is.Equal(methods[1].Name, "Wave")
+	is.Equal(methods[1].InputExample, nil)
+	is.Equal(methods[1].OutputExample, nil)
+
+	parser = New("./testdata/examplefiles/malformed")
+	parser.ExampleDir = "./testdata/examplefiles"
+	_, err = parser.Parse()
+	is.True(err != nil) // invalid JSON
+
+	parser = New("./testdata/examplefiles")
+	_, err = parser.Parse()
+	is.True(err != nil) // missing file outside ExampleDir
+}
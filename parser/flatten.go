@@ -0,0 +1,61 @@
+package parser
+
+// FlatField is a Field along with its fully-qualified path.
+type FlatField struct {
+	// Path is the dotted path to the field, starting with the
+	// object name. For example, GreetResponse.Greeting.Text.
+	Path string `json:"path"`
+	// Type is the type of the field.
+	Type FieldType `json:"type"`
+	// Comment is the comment for the field.
+	Comment string `json:"comment"`
+	// Field is the field itself.
+	Field Field `json:"field"`
+}
+
+// FlattenFields gets every field across all objects, descending into
+// fields that are objects, slices of objects or maps of objects.
+// The fields of slice elements and map values are under the path of
+// the slice or map field, like GetStatsResponse.Greetings.Text.
+// Recursive types are only descended into once per path.
+func (d *Definition) FlattenFields() []FlatField {
+	var flatFields []FlatField
+	for i := range d.Objects {
+		flatFields = d.flattenFields(flatFields, &d.Objects[i], d.Objects[i].Name, map[string]bool{})
+	}
+	return flatFields
+}
+
+func (d *Definition) flattenFields(flatFields []FlatField, object *Object, path string, seen map[string]bool) []FlatField {
+	seen[object.Name] = true
+	defer delete(seen, object.Name)
+	for _, field := range object.Fields {
+		fieldPath := path + "." + field.Name
+		flatFields = append(flatFields, FlatField{
+			Path:    fieldPath,
+			Type:    field.Type,
+			Comment: field.Comment,
+			Field:   field,
+		})
+		var objectName string
+		switch {
+		case field.Type.IsObject:
+			objectName = field.Type.CleanObjectName
+		case field.Type.IsMap() && field.Type.Map.ElementIsObject:
+			objectName = field.Type.Map.CleanElementType
+		default:
+			continue
+		}
+		if seen[objectName] {
+			continue
+		}
+		fieldObject, err := d.Object(objectName)
+		if err != nil {
+			// every object a field refers to is parsed, so this
+			// only happens for hand-made definitions
+			continue
+		}
+		flatFields = d.flattenFields(flatFields, fieldObject, fieldPath, seen)
+	}
+	return flatFields
+}
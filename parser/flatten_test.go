@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFlattenFields(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	flatFields := def.FlattenFields()
+	flatFieldsByPath := make(map[string]FlatField)
+	for _, flatField := range flatFields {
+		flatFieldsByPath[flatField.Path] = flatField
+	}
+	text, ok := flatFieldsByPath["GreetResponse.Greeting.Text"]
+	is.True(ok) // nested field
+	is.Equal(text.Type.TypeName, "string")
+	is.Equal(text.Comment, "Text is the message.")
+	_, ok = flatFieldsByPath["GreetResponse.Greeting"]
+	is.True(ok) // object field
+	_, ok = flatFieldsByPath["GetGreetingsRequest.Page.Cursor"]
+	is.True(ok) // imported object field
+}
+
+func TestFlattenFieldsMaps(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/maps"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	flatFields := def.FlattenFields()
+	flatFieldsByPath := make(map[string]FlatField)
+	for _, flatField := range flatFields {
+		flatFieldsByPath[flatField.Path] = flatField
+	}
+	text, ok := flatFieldsByPath["GetStatsResponse.Greetings.Text"]
+	is.True(ok) // field of map element object
+	is.Equal(text.Comment, "Text is the message.")
+	_, ok = flatFieldsByPath["GetStatsResponse.Counts"]
+	is.True(ok) // map of scalars
+}
+
+func TestFlattenFieldsRecursive(t *testing.T) {
+	is := is.New(t)
+
+	def := &Definition{
+		Objects: []Object{
+			{
+				Name: "Node",
+				Fields: []Field{
+					{
+						Name: "Name",
+						Type: FieldType{TypeName: "string"},
+					},
+					{
+						Name: "Children",
+						Type: FieldType{TypeName: "Node", CleanObjectName: "Node", IsObject: true, Multiple: true},
+					},
+				},
+			},
+		},
+	}
+	flatFields := def.FlattenFields()
+	is.Equal(len(flatFields), 2)
+	is.Equal(flatFields[0].Path, "Node.Name")
+	is.Equal(flatFields[1].Path, "Node.Children")
+}
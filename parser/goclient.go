@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// GoClient generates Go source code for a client package called
+// packageName, with a type for each Service and a method for each
+// Method that POSTs the request to the server and decodes the
+// response.
+// Objects are included as Go types, without the Error field which
+// the client turns into an error.
+// Streaming methods are left out, since they cannot be called with
+// a single request.
+func (d *Definition) GoClient(packageName string) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by oto; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", packageName)
+	fmt.Fprintln(&buf, "import (")
+	for _, importPath := range []string{"bytes", "context", "encoding/json", "errors", "fmt", "io", "net/http", "time"} {
+		fmt.Fprintf(&buf, "\t%q\n", importPath)
+	}
+	importPaths := make([]string, 0, len(d.Imports))
+	for importPath := range d.Imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+	if len(importPaths) > 0 {
+		fmt.Fprintln(&buf)
+	}
+	for _, importPath := range importPaths {
+		fmt.Fprintf(&buf, "\t%s %q\n", d.Imports[importPath], importPath)
+	}
+	fmt.Fprintln(&buf, ")")
+	buf.WriteString(goClientPreamble)
+	for _, service := range d.Services {
+		fmt.Fprintln(&buf)
+		writeGoComment(&buf, service.Comment, "")
+		fmt.Fprintf(&buf, "type %s struct {\n\tclient *Client\n}\n\n", service.Name)
+		fmt.Fprintf(&buf, "// New%s makes a new client for accessing %s services.\n", service.Name, service.Name)
+		fmt.Fprintf(&buf, "func New%s(client *Client) *%s {\n\treturn &%s{client: client}\n}\n", service.Name, service.Name, service.Name)
+		for _, method := range service.Methods {
+			if method.Streaming || method.StreamingDirection == "client" {
+				continue
+			}
+			fmt.Fprintln(&buf)
+			writeGoComment(&buf, method.Comment, "")
+			fmt.Fprintf(&buf, "func (s *%s) %s(ctx context.Context, r %s) (*%s, error) {\n", service.Name, method.Name, method.InputObject.TypeName, method.OutputObject.TypeName)
+			fmt.Fprintf(&buf, "\tvar response struct {\n\t\t%s\n\t\tError json.RawMessage `json:\"error\"`\n\t}\n", method.OutputObject.TypeName)
+			fmt.Fprintf(&buf, "\tif err := s.client.do(ctx, %q, r, &response); err != nil {\n", service.Name+"."+method.Name)
+			fmt.Fprintln(&buf, "\t\treturn nil, err\n\t}")
+			fmt.Fprintf(&buf, "\tif err := responseError(response.Error); err != nil {\n\t\treturn nil, fmt.Errorf(\"%s.%s: %%w\", err)\n\t}\n", service.Name, method.Name)
+			fmt.Fprintf(&buf, "\treturn &response.%s, nil\n}\n", method.OutputObject.TypeName)
+		}
+	}
+	for _, object := range d.Objects {
+		if object.Imported {
+			continue
+		}
+		fmt.Fprintln(&buf)
+		writeGoComment(&buf, object.Comment, "")
+		fmt.Fprintf(&buf, "type %s struct {\n", object.Name)
+		for _, field := range object.Fields {
+			if field.OutputOnly || field.IsExcludedIn("go") {
+				continue
+			}
+			writeGoComment(&buf, field.Comment, "\t")
+			omitEmpty := ""
+			if field.OmitEmpty {
+				omitEmpty = ",omitempty"
+			}
+			fmt.Fprintf(&buf, "\t%s %s `json:\"%s%s\"`\n", field.Name, field.Type, field.NameLowerCamel, omitEmpty)
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", errors.Wrap(err, "format")
+	}
+	return string(b), nil
+}
+
+// goClientPreamble is the Client type shared by the services in the
+// code generated by GoClient.
+const goClientPreamble = `
+// Client is used to access the services.
+type Client struct {
+	// RemoteHost is the URL of the remote server that this Client
+	// should access, like https://example.com/oto/.
+	RemoteHost string
+	// HTTPClient is the http.Client to use when making HTTP requests.
+	HTTPClient *http.Client
+	// BeforeRequest is an optional hook that gives you the opportunity
+	// to inspect or modify the request before it is made.
+	// Useful for adding auth headers, for example.
+	BeforeRequest func(r *http.Request) error
+}
+
+// New makes a new Client.
+func New(remoteHost string) *Client {
+	return &Client{
+		RemoteHost: remoteHost,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// do POSTs the request to the endpoint, and decodes the response.
+func (c *Client) do(ctx context.Context, endpoint string, request, response interface{}) error {
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("%s: marshal request: %w", endpoint, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.RemoteHost+endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("%s: new request: %w", endpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.BeforeRequest != nil {
+		if err := c.BeforeRequest(req); err != nil {
+			// don't wrap this error, it belongs to the user
+			return err
+		}
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: read response body: %w", endpoint, err)
+	}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: (%d) %s", endpoint, resp.StatusCode, responseBody)
+		}
+		return fmt.Errorf("%s: unmarshal response: %w", endpoint, err)
+	}
+	return nil
+}
+
+// responseError gets the error described by the error field of
+// a response, or nil if there was no error.
+func responseError(raw json.RawMessage) error {
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == ` + "`" + `""` + "`" + ` {
+		return nil
+	}
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return errors.New(message)
+	}
+	return errors.New(string(raw))
+}
+`
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGoClient(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	src, err := def.GoClient("greeterclient")
+	is.NoErr(err)
+	for _, should := range []string{
+		"package greeterclient\n",
+		"\t\"time\"\n\n\tservices \"",
+		"func NewGreeterService(client *Client) *GreeterService {",
+		"// Greet creates a Greeting for one or more people.\nfunc (s *GreeterService) Greet(ctx context.Context, r GreetRequest) (*GreetResponse, error) {",
+		"\tif err := s.client.do(ctx, \"GreeterService.Greet\", r, &response); err != nil {",
+		"\tif err := responseError(response.Error); err != nil {",
+		"type GreetRequest struct {",
+		"\tPage services.Page `json:\"page\"`\n",
+	} {
+		if !strings.Contains(src, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+	is.True(!strings.Contains(src, "\tError string")) // error field is handled by the client
+}
@@ -0,0 +1,274 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GraphQLSDL generates a GraphQL schema in the schema definition
+// language for the services and objects.
+// Each method becomes a field of the Mutation type, taking the input
+// object as its input argument and returning the output object.
+// Objects used by method inputs become input types and the others
+// become types; objects used by both become a type and an input type
+// with the Input suffix. OutputOnly fields, like error, are left out
+// of input types.
+// Pointer, omitempty, slice and map fields are nullable, and slice
+// elements are only nullable for slices of pointers. Times are the
+// DateTime scalar, maps are the JSON scalar, and fields with options
+// metadata are enums named after the object and field.
+// GraphQL's Int is 32-bit, so integer types that may not fit in it
+// (int, int64, uint, uint32 and uint64) are the BigInt scalar,
+// which is still sent as a JSON number. Float is not used for them,
+// since it would lose precision above 2^53.
+func (d *Definition) GraphQLSDL() (string, error) {
+	g := &graphQLSchema{
+		def:     d,
+		inputs:  make(map[string]bool),
+		outputs: make(map[string]bool),
+		scalars: make(map[string]bool),
+		enums:   make(map[string][]string),
+	}
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if err := g.addObjects(g.inputs, method.InputObject.CleanObjectName); err != nil {
+				return "", errors.Wrapf(err, "%s.%s: input object", service.Name, method.Name)
+			}
+			if err := g.addObjects(g.outputs, method.OutputObject.CleanObjectName); err != nil {
+				return "", errors.Wrapf(err, "%s.%s: output object", service.Name, method.Name)
+			}
+		}
+	}
+	var types bytes.Buffer
+	if len(d.Services) > 0 {
+		fmt.Fprintln(&types)
+		fmt.Fprintln(&types, "type Mutation {")
+		for _, service := range d.Services {
+			for _, method := range service.Methods {
+				writeGraphQLDescription(&types, "\t", method.Comment)
+				var args string
+				if input, err := d.Object(method.InputObject.CleanObjectName); err == nil && len(graphQLFields(*input, true)) > 0 {
+					args = "(input: " + g.objectName(input.Name, true) + "!)"
+				}
+				fmt.Fprintf(&types, "\t%s%s%s: %s!\n", camelizeDown(service.Name), method.Name, args, g.objectName(method.OutputObject.CleanObjectName, false))
+			}
+		}
+		fmt.Fprintln(&types, "}")
+	}
+	for _, object := range d.Objects {
+		// GraphQL types must have fields, so empty ones are skipped
+		fields := graphQLFields(object, false)
+		if len(fields) > 0 && (g.outputs[object.Name] || !g.inputs[object.Name]) {
+			if err := g.writeObject(&types, "type", object, fields, false); err != nil {
+				return "", err
+			}
+		}
+		inputFields := graphQLFields(object, true)
+		if len(inputFields) > 0 && g.inputs[object.Name] {
+			if err := g.writeObject(&types, "input", object, inputFields, true); err != nil {
+				return "", err
+			}
+		}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# Code generated by oto; DO NOT EDIT.")
+	scalars := make([]string, 0, len(g.scalars))
+	for scalar := range g.scalars {
+		scalars = append(scalars, scalar)
+	}
+	sort.Strings(scalars)
+	for _, scalar := range scalars {
+		fmt.Fprintln(&buf)
+		writeGraphQLDescription(&buf, "", graphQLScalarDescriptions[scalar])
+		fmt.Fprintf(&buf, "scalar %s\n", scalar)
+	}
+	enums := make([]string, 0, len(g.enums))
+	for enum := range g.enums {
+		enums = append(enums, enum)
+	}
+	sort.Strings(enums)
+	for _, enum := range enums {
+		fmt.Fprintln(&buf)
+		fmt.Fprintf(&buf, "enum %s {\n", enum)
+		for _, value := range g.enums[enum] {
+			fmt.Fprintf(&buf, "\t%s\n", value)
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+	buf.Write(types.Bytes())
+	return buf.String(), nil
+}
+
+// graphQLSchema holds the state for generating a GraphQL schema.
+type graphQLSchema struct {
+	def *Definition
+	// inputs and outputs are the names of objects used by method
+	// inputs and outputs, including their dependencies.
+	inputs  map[string]bool
+	outputs map[string]bool
+	// scalars are the custom scalars that are used.
+	scalars map[string]bool
+	// enums are the values of the enums made from options metadata,
+	// by name.
+	enums map[string][]string
+}
+
+// graphQLScalarDescriptions are the descriptions of the custom
+// scalars.
+var graphQLScalarDescriptions = map[string]string{
+	"BigInt":   "BigInt is a whole number that may not fit in Int, sent as a JSON number.",
+	"DateTime": "DateTime is a date and time in RFC 3339 format.",
+	"JSON":     "JSON is any JSON value.",
+}
+
+// graphQLScalarTypes maps Go scalar types to GraphQL scalar types.
+// Only integers that always fit in 32 bits are Int.
+var graphQLScalarTypes = map[string]string{
+	"string":  "String",
+	"bool":    "Boolean",
+	"int":     "BigInt",
+	"int8":    "Int",
+	"int16":   "Int",
+	"int32":   "Int",
+	"int64":   "BigInt",
+	"uint":    "BigInt",
+	"uint8":   "Int",
+	"uint16":  "Int",
+	"uint32":  "BigInt",
+	"uint64":  "BigInt",
+	"float32": "Float",
+	"float64": "Float",
+}
+
+// graphQLNamePattern matches valid GraphQL names.
+var graphQLNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// addObjects adds the named object and the objects it depends on to
+// names.
+func (g *graphQLSchema) addObjects(names map[string]bool, name string) error {
+	objects, err := g.def.ObjectWithDependencies(name)
+	if err != nil {
+		return errors.Wrap(err, name)
+	}
+	for _, object := range objects {
+		names[object.Name] = true
+	}
+	return nil
+}
+
+// objectName gets the GraphQL name for the named object. Objects
+// that are both inputs and outputs get the Input suffix when used
+// as inputs.
+func (g *graphQLSchema) objectName(name string, input bool) string {
+	if input && g.outputs[name] {
+		return name + "Input"
+	}
+	return name
+}
+
+// writeObject writes the object as a GraphQL type or input type,
+// given by kind.
+func (g *graphQLSchema) writeObject(buf *bytes.Buffer, kind string, object Object, fields []Field, input bool) error {
+	fmt.Fprintln(buf)
+	writeGraphQLDescription(buf, "", object.Comment)
+	fmt.Fprintf(buf, "%s %s {\n", kind, g.objectName(object.Name, input))
+	for _, field := range fields {
+		typ, err := g.fieldType(object, field, input)
+		if err != nil {
+			return errors.Wrapf(err, "%s.%s", object.Name, field.Name)
+		}
+		writeGraphQLDescription(buf, "\t", field.Comment)
+		fmt.Fprintf(buf, "\t%s: %s\n", field.NameLowerCamel, typ)
+	}
+	fmt.Fprintln(buf, "}")
+	return nil
+}
+
+// fieldType gets the GraphQL type for the field.
+func (g *graphQLSchema) fieldType(object Object, field Field, input bool) (string, error) {
+	ftype := field.Type
+	nullable := ftype.IsOptional() || field.OmitEmpty || field.Optional
+	var typ string
+	switch {
+	case ftype.IsObject:
+		typ = g.objectName(ftype.CleanObjectName, input)
+	case isTimeFieldType(ftype):
+		typ = "DateTime"
+	case ftype.IsMap(), ftype.CleanObjectName == "interface{}":
+		typ = "JSON"
+		// nil maps are null
+		nullable = true
+	case ftype.IsEnum:
+		enum, err := g.def.Enum(ftype.CleanObjectName)
+		if err != nil {
+			return "", errors.Wrapf(err, "Enum(%q)", ftype.CleanObjectName)
+		}
+		// enums are their base type on the wire
+		typ = graphQLScalarTypes[enum.BaseType]
+	case ftype.CleanObjectName == "":
+		// built-in fields, like Error, only have a TypeName
+		typ = graphQLScalarTypes[ftype.TypeName]
+	default:
+		typ = graphQLScalarTypes[ftype.CleanObjectName]
+	}
+	if typ == "" {
+		return "", errors.Errorf("unsupported type %s", ftype)
+	}
+	if _, ok := graphQLScalarDescriptions[typ]; ok {
+		g.scalars[typ] = true
+	}
+	if options, ok := field.Metadata["options"].([]interface{}); ok && len(options) > 0 {
+		values := make([]string, len(options))
+		for i, option := range options {
+			value, ok := option.(string)
+			if !ok || !graphQLNamePattern.MatchString(value) || value == "true" || value == "false" || value == "null" {
+				return "", errors.Errorf("options: %v is not a valid GraphQL enum value", option)
+			}
+			values[i] = value
+		}
+		typ = object.Name + field.Name
+		g.enums[typ] = values
+	}
+	if ftype.Multiple {
+		if !ftype.ElementIsPointer {
+			typ += "!"
+		}
+		return "[" + typ + "]", nil
+	}
+	if nullable {
+		return typ, nil
+	}
+	return typ + "!", nil
+}
+
+// graphQLFields gets the fields of the object that are included in
+// GraphQL schemas. OutputOnly fields are left out of input types.
+func graphQLFields(object Object, input bool) []Field {
+	var fields []Field
+	for _, field := range object.Fields {
+		if field.IsExcludedIn("graphql") || (input && field.OutputOnly) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// writeGraphQLDescription writes comment as a block string
+// description with the indent.
+func writeGraphQLDescription(buf *bytes.Buffer, indent, comment string) {
+	if comment == "" {
+		return
+	}
+	comment = strings.ReplaceAll(comment, `"""`, `\"""`)
+	fmt.Fprintf(buf, "%s\"\"\"\n", indent)
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintf(buf, "%s%s\n", indent, line)
+	}
+	fmt.Fprintf(buf, "%s\"\"\"\n", indent)
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGraphQLSDL(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/graphql"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	s, err := def.GraphQLSDL()
+	is.NoErr(err)
+	is.True(strings.HasPrefix(s, "# Code generated by oto; DO NOT EDIT.\n"))
+	for _, should := range []string{
+		"\nscalar BigInt\n",
+		"\nscalar DateTime\n",
+		"\nscalar JSON\n",
+		"\nenum PostKind {\n\ttext\n\timage\n}\n",
+		`type Mutation {
+	"""
+	Create makes a new post.
+	"""
+	postServiceCreate(input: CreateRequest!): CreateResponse!
+	"""
+	List gets all posts.
+	"""
+	postServiceList: ListResponse!
+	"""
+	Restore puts back a post returned by Create.
+	"""
+	postServiceRestore(input: CreateResponseInput!): CreateResponse!
+}
+`,
+		`"""
+CreateRequest is the input for Create.
+"""
+input CreateRequest {
+	post: PostInput!
+	"""
+	Notify sets whether to notify followers.
+	"""
+	notify: Boolean
+}
+`,
+		`type CreateResponse {
+	post: Post
+	"""
+	Error is string explaining what went wrong. Empty if everything was fine.
+	"""
+	error: String
+}
+`,
+		"type ListResponse {\n\tposts: [Post!]\n",
+		`type Post {
+	title: String!
+	"""
+	Kind is the kind of post.
+	"""
+	kind: PostKind!
+	tags: [String!]
+	published: DateTime
+	views: BigInt!
+	likes: Int!
+	score: Float!
+	draft: Boolean!
+	attrs: JSON
+	related: [Post]
+}
+`,
+		"input PostInput {\n\ttitle: String!\n",
+		"\trelated: [PostInput]\n}\n",
+		"input CreateResponseInput {\n\tpost: PostInput\n}\n", // no error field
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s\n\ngot: %s", should, s)
+		}
+	}
+	is.True(!strings.Contains(s, "ListRequest"))        // no fields
+	is.True(!strings.Contains(s, "type CreateRequest")) // only an input
+}
+
+func TestGraphQLSDLInvalidOptions(t *testing.T) {
+	is := is.New(t)
+	def := Definition{
+		Objects: []Object{
+			{
+				Name: "Size",
+				Fields: []Field{
+					{
+						Name:           "Value",
+						NameLowerCamel: "value",
+						Type:           FieldType{TypeName: "string", ObjectName: "string", CleanObjectName: "string"},
+						Metadata: map[string]interface{}{
+							"options": []interface{}{"small", "extra-large"},
+						},
+					},
+				},
+			},
+		},
+	}
+	_, err := def.GraphQLSDL()
+	is.True(err != nil)
+	is.Equal(err.Error(), "Size.Value: options: extra-large is not a valid GraphQL enum value")
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GoInterfaces generates Go source code describing each Service as
+// a Go interface, including comments.
+// Method signatures match the definition, so they include the
+// context.Context, error results and channels for streaming.
+// Useful for scaffolding server implementations.
+func (d *Definition) GoInterfaces() (string, error) {
+	var buf bytes.Buffer
+	for i, service := range d.Services {
+		if i > 0 {
+			fmt.Fprintln(&buf)
+		}
+		writeGoComment(&buf, service.Comment, "")
+		fmt.Fprintf(&buf, "type %s interface {\n", service.Name)
+		for _, method := range service.Methods {
+			writeGoComment(&buf, method.Comment, "\t")
+			fmt.Fprintf(&buf, "\t%s(%s) %s\n", method.Name, goInterfaceParams(method), goInterfaceResults(method))
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", errors.Wrap(err, "format")
+	}
+	return string(b), nil
+}
+
+// goInterfaceParams gets the parameters of method, like
+// context.Context, GreetRequest.
+// Client and bidi streaming methods take a receive-only channel.
+func goInterfaceParams(method Method) string {
+	params := method.InputObject.String()
+	if method.StreamingDirection == "client" || method.StreamingDirection == "bidi" {
+		params = "<-chan " + params
+	}
+	if method.TakesContext {
+		params = "context.Context, " + params
+	}
+	return params
+}
+
+// goInterfaceResults gets the results of method, like
+// (GreetResponse, error).
+// Server and bidi streaming methods return a receive-only channel.
+func goInterfaceResults(method Method) string {
+	results := method.OutputObject.String()
+	if method.Streaming {
+		results = "<-chan " + results
+	}
+	if !method.ReturnsError {
+		return results
+	}
+	errorType := method.ErrorType
+	if errorType == "" {
+		errorType = "error"
+	}
+	return "(" + results + ", " + errorType + ")"
+}
+
+// writeGoComment writes each line of comment as a // comment.
+func writeGoComment(w io.Writer, comment, indent string) {
+	s := bufio.NewScanner(strings.NewReader(comment))
+	for s.Scan() {
+		fmt.Fprintf(w, "%s// %s\n", indent, s.Text())
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGoInterfaces(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	src, err := def.GoInterfaces()
+	is.NoErr(err)
+	for _, should := range []string{
+		"// GreeterService is a polite API.\n// You will love it.\ntype GreeterService interface {",
+		"\t// Greet creates a Greeting for one or more people.\n\tGreet(GreetRequest) GreetResponse\n",
+		"\tGetGreetings(GetGreetingsRequest) GetGreetingsResponse\n",
+		"type Welcomer interface {",
+	} {
+		if !strings.Contains(src, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestGoInterfacesSignatures(t *testing.T) {
+	is := is.New(t)
+	for _, test := range []struct {
+		pattern string
+		should  []string
+	}{
+		{
+			pattern: "./testdata/errortypes",
+			should: []string{
+				"\tGreet(GreetRequest) (GreetResponse, *ValidationError)\n",
+				"\tFarewell(FarewellRequest) (FarewellResponse, error)\n",
+				"\tWave(WaveRequest) WaveResponse\n",
+			},
+		},
+		{
+			pattern: "./testdata/streamingdirections",
+			should: []string{
+				"\tGet(Message) Message\n",
+				"\tUpload(<-chan Message) UploadResponse\n",
+				"\tWatch(WatchRequest) <-chan Message\n",
+				"\tChat(<-chan Message) <-chan Message\n",
+			},
+		},
+		{
+			pattern: "./testdata/ignoreimports",
+			should: []string{
+				"\tRun(RunRequest) RunResponse\n",
+				"\tSchedule(context.Context, ScheduleRequest) ScheduleResponse\n",
+			},
+		},
+	} {
+		parser := New(test.pattern)
+		parser.Verbose = testing.Verbose()
+		def, err := parser.Parse()
+		is.NoErr(err)
+		src, err := def.GoInterfaces()
+		is.NoErr(err)
+		for _, should := range test.should {
+			if !strings.Contains(src, should) {
+				t.Errorf("%s: missing: %s\n\ngot: %s", test.pattern, should, src)
+			}
+		}
+	}
+}
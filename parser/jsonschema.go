@@ -0,0 +1,302 @@
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// JSONSchema generates a JSON Schema document for the named object,
+// with the objects it depends on under $defs.
+// Fields that are not pointers or omitempty are required, and
+// options metadata and enum types become an enum.
+// Integers are integer, and times are strings in date-time format.
+// Returns ErrNotFound if there is no object called objectName.
+func (d *Definition) JSONSchema(objectName string) (json.RawMessage, error) {
+	objects, err := d.ObjectWithDependencies(objectName)
+	if err != nil {
+		return nil, err
+	}
+	var root Object
+	defs := make(map[string]*jsonSchema)
+	for _, object := range objects {
+		if object.Name == objectName {
+			root = object
+			continue
+		}
+		defs[object.Name] = d.jsonSchemaForObject(object, "jsonschema", jsonSchemaBundleRefPrefix)
+	}
+	schema := d.jsonSchemaForObject(root, "jsonschema", jsonSchemaBundleRefPrefix)
+	if objectsReference(objects, objectName) {
+		// recursive types refer to themselves
+		defs[objectName] = d.jsonSchemaForObject(root, "jsonschema", jsonSchemaBundleRefPrefix)
+	}
+	schema.Schema = jsonSchemaDraft
+	if len(defs) > 0 {
+		schema.Defs = defs
+	}
+	b, err := json.MarshalIndent(schema, "", "\t")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal")
+	}
+	return b, nil
+}
+
+// objectsReference gets whether any of the objects have a field that
+// refers to the object called name.
+func objectsReference(objects []Object, name string) bool {
+	for _, object := range objects {
+		for _, field := range object.Fields {
+			if field.Type.IsObject && field.Type.CleanObjectName == name {
+				return true
+			}
+			if field.Type.IsMap() && field.Type.Map.ElementIsObject && field.Type.Map.CleanElementType == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JSONSchemaBundle generates a single JSON Schema document describing
+// every object under $defs, with a top-level oneOf referencing the
+// request and response objects of each method.
+func (d *Definition) JSONSchemaBundle() (json.RawMessage, error) {
+	bundle := &jsonSchema{
+		Schema: jsonSchemaDraft,
+		Defs:   make(map[string]*jsonSchema),
+	}
+	for _, object := range d.Objects {
+		bundle.Defs[object.Name] = d.jsonSchemaForObject(object, "jsonschema", jsonSchemaBundleRefPrefix)
+	}
+	seen := make(map[string]bool)
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			for _, name := range []string{method.InputObject.CleanObjectName, method.OutputObject.CleanObjectName} {
+				if seen[name] {
+					continue
+				}
+				if _, ok := bundle.Defs[name]; !ok {
+					return nil, errors.Errorf("%s.%s: %s: %s", service.Name, method.Name, name, ErrNotFound)
+				}
+				seen[name] = true
+				bundle.OneOf = append(bundle.OneOf, &jsonSchema{Ref: jsonSchemaBundleRefPrefix + name})
+			}
+		}
+	}
+	b, err := json.MarshalIndent(bundle, "", "\t")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal")
+	}
+	return b, nil
+}
+
+// jsonSchemaDraft is the JSON Schema dialect of generated schemas.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaBundleRefPrefix is the prefix of references to objects
+// in the JSONSchemaBundle.
+const jsonSchemaBundleRefPrefix = "#/$defs/"
+
+// jsonSchema is a JSON Schema describing an Object or Field.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+	OneOf                []*jsonSchema          `json:"oneOf,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 jsonSchemaType         `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	ContentMediaType     string                 `json:"contentMediaType,omitempty"`
+	// False is true for the schema that nothing is valid against,
+	// which is written as false, like additionalProperties: false.
+	False bool `json:"-"`
+	// Extensions are extra keywords, like OpenAPI vendor extensions.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON writes the schema with its Extensions, or false.
+func (s *jsonSchema) MarshalJSON() ([]byte, error) {
+	if s.False {
+		return []byte("false"), nil
+	}
+	type schema jsonSchema
+	return marshalWithExtensions((*schema)(s), s.Extensions)
+}
+
+// UnmarshalJSON reads a schema, which may be true or false.
+func (s *jsonSchema) UnmarshalJSON(b []byte) error {
+	var valid bool
+	if err := json.Unmarshal(b, &valid); err == nil {
+		*s = jsonSchema{False: !valid}
+		return nil
+	}
+	type schema jsonSchema
+	return json.Unmarshal(b, (*schema)(s))
+}
+
+// jsonSchemaForObject gets the JSON Schema for the object.
+// Fields are required unless they are omitempty, optional or
+// pointers.
+// Fields excluded from the target generator are left out.
+// References to other objects begin with refPrefix.
+func (d *Definition) jsonSchemaForObject(object Object, target, refPrefix string) *jsonSchema {
+	schema := &jsonSchema{
+		Type:        jsonSchemaType{"object"},
+		Description: object.Comment,
+		Properties:  make(map[string]*jsonSchema),
+	}
+	for _, field := range object.FieldsIn(target) {
+		schema.Properties[field.NameLowerCamel] = d.jsonSchemaForField(field, refPrefix)
+		if !field.OmitEmpty && !field.Optional && !field.Type.IsOptional() {
+			schema.Required = append(schema.Required, field.NameLowerCamel)
+		}
+	}
+	return schema
+}
+
+// jsonSchemaForField gets the JSON Schema for the field.
+// Pointer fields, and fields with nullable: true metadata, may
+// be null. The options metadata limits the values to an enum.
+// Times with format: "date" metadata are in date format.
+func (d *Definition) jsonSchemaForField(field Field, refPrefix string) *jsonSchema {
+	schema := d.jsonSchemaForType(field.Type, refPrefix)
+	if isTimeFieldType(field.Type) && field.Metadata["format"] == "date" {
+		if schema.Items != nil {
+			schema.Items.Format = "date"
+		} else {
+			schema.Format = "date"
+		}
+	}
+	if options, ok := field.Metadata["options"].([]interface{}); ok && len(options) > 0 {
+		if schema.Items != nil {
+			schema.Items.Enum = options
+		} else {
+			schema.Enum = options
+		}
+	}
+	nullable, _ := field.Metadata["nullable"].(bool)
+	if field.Type.ElementIsPointer {
+		schema.Items = nullableJSONSchema(schema.Items)
+	} else if field.Type.IsOptional() {
+		nullable = true
+	}
+	if nullable {
+		schema = nullableJSONSchema(schema)
+	}
+	schema.Description = field.Comment
+	return schema
+}
+
+// nullableJSONSchema gets a version of schema that also allows null.
+func nullableJSONSchema(schema *jsonSchema) *jsonSchema {
+	switch {
+	case schema.Ref != "":
+		return &jsonSchema{AnyOf: []*jsonSchema{schema, {Type: jsonSchemaType{"null"}}}}
+	case len(schema.Type) > 0:
+		schema.Type = append(schema.Type, "null")
+		if len(schema.Enum) > 0 {
+			schema.Enum = append(schema.Enum, nil)
+		}
+	}
+	return schema
+}
+
+// jsonSchemaForType gets the JSON Schema for a field type.
+// References to objects begin with refPrefix.
+func (d *Definition) jsonSchemaForType(ftype FieldType, refPrefix string) *jsonSchema {
+	var schema *jsonSchema
+	switch {
+	case ftype.IsObject:
+		schema = &jsonSchema{Ref: refPrefix + ftype.CleanObjectName}
+	case isTimeFieldType(ftype):
+		schema = &jsonSchema{Type: jsonSchemaType{"string"}, Format: "date-time"}
+	case ftype.IsMap():
+		element := &jsonSchema{}
+		switch {
+		case ftype.Map.ElementIsObject:
+			element = &jsonSchema{Ref: refPrefix + ftype.Map.CleanElementType}
+		case ftype.Map.ElementIsEnum:
+			element = d.jsonSchemaForEnum(ftype.Map.CleanElementType)
+		default:
+			element = jsonSchemaForScalar(ftype.Map.CleanElementType)
+		}
+		if ftype.Map.ElementIsMultiple {
+			element = &jsonSchema{Type: jsonSchemaType{"array"}, Items: element}
+		}
+		schema = &jsonSchema{Type: jsonSchemaType{"object"}, AdditionalProperties: element}
+	case ftype.IsEnum:
+		schema = d.jsonSchemaForEnum(ftype.CleanObjectName)
+	case ftype.CleanObjectName == "":
+		// built-in fields, like Error, only have a TypeName
+		schema = jsonSchemaForScalar(ftype.TypeName)
+	default:
+		schema = jsonSchemaForScalar(ftype.CleanObjectName)
+	}
+	if ftype.Multiple {
+		return &jsonSchema{Type: jsonSchemaType{"array"}, Items: schema}
+	}
+	return schema
+}
+
+// jsonSchemaForEnum gets the JSON Schema for the enum called name,
+// which is its base type limited to its values.
+func (d *Definition) jsonSchemaForEnum(name string) *jsonSchema {
+	enum, err := d.Enum(name)
+	if err != nil {
+		return &jsonSchema{}
+	}
+	schema := jsonSchemaForScalar(enum.BaseType)
+	for _, value := range enum.Values {
+		schema.Enum = append(schema.Enum, value.Value)
+	}
+	return schema
+}
+
+// jsonSchemaForScalar gets the JSON Schema for the Go scalar type.
+// Types like interface{} are left unconstrained.
+func jsonSchemaForScalar(goType string) *jsonSchema {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return &jsonSchema{Type: jsonSchemaType{"integer"}}
+	}
+	names, ok := scalarLanguageTypes(goType)
+	if !ok {
+		return &jsonSchema{}
+	}
+	switch names.JS {
+	case "string", "number", "boolean", "object":
+		return &jsonSchema{Type: jsonSchemaType{names.JS}}
+	}
+	return &jsonSchema{}
+}
+
+// jsonSchemaType is the type keyword of a JSON Schema, which is a
+// single type name, or a list of them like ["string", "null"].
+type jsonSchemaType []string
+
+// MarshalJSON writes a single type as a string, and many types as
+// a list.
+func (t jsonSchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON reads a single type or a list of types.
+func (t *jsonSchemaType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*t = jsonSchemaType{name}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
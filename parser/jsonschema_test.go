@@ -0,0 +1,254 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestJSONSchemaBundle(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	b, err := def.JSONSchemaBundle()
+	is.NoErr(err)
+	var bundle map[string]interface{}
+	is.NoErr(json.Unmarshal(b, &bundle))
+	defs, ok := bundle["$defs"].(map[string]interface{})
+	is.True(ok)
+	is.Equal(len(defs), len(def.Objects))
+	for _, object := range def.Objects {
+		_, ok := defs[object.Name]
+		if !ok {
+			t.Errorf("missing $defs: %s", object.Name)
+		}
+	}
+	oneOf, ok := bundle["oneOf"].([]interface{})
+	is.True(ok)
+	var refs []string
+	for _, item := range oneOf {
+		refs = append(refs, item.(map[string]interface{})["$ref"].(string))
+	}
+	is.True(isInSlice(refs, "#/$defs/GreetRequest"))
+	is.True(isInSlice(refs, "#/$defs/GreetResponse"))
+	is.True(!isInSlice(refs, "#/$defs/Greeting")) // not a request or response
+
+	greetResponse := defs["GreetResponse"].(map[string]interface{})
+	greeting := greetResponse["properties"].(map[string]interface{})["greeting"].(map[string]interface{})
+	anyOf := greeting["anyOf"].([]interface{}) // *Greeting may be null
+	is.Equal(anyOf[0].(map[string]interface{})["$ref"], "#/$defs/Greeting")
+
+	// every reference resolves to something in $defs
+	var checkRefs func(v interface{})
+	checkRefs = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if ref, ok := v["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/$defs/")
+				if _, ok := defs[name]; !ok {
+					t.Errorf("unresolved $ref: %s", ref)
+				}
+			}
+			for _, child := range v {
+				checkRefs(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				checkRefs(child)
+			}
+		}
+	}
+	checkRefs(bundle)
+}
+
+func TestJSONSchema(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	for _, object := range def.Objects {
+		b, err := def.JSONSchema(object.Name)
+		is.NoErr(err)
+		var schema jsonSchema
+		is.NoErr(json.Unmarshal(b, &schema))
+		is.Equal(schema.Schema, "https://json-schema.org/draft/2020-12/schema")
+		is.Equal(schema.Type, jsonSchemaType{"object"})
+		is.Equal(len(schema.Properties), len(object.Fields))
+		// every reference resolves to something in $defs
+		defs := schema.Defs
+		var checkRefs func(schema *jsonSchema)
+		checkRefs = func(schema *jsonSchema) {
+			if schema == nil {
+				return
+			}
+			if schema.Ref != "" {
+				name := strings.TrimPrefix(schema.Ref, "#/$defs/")
+				if _, ok := defs[name]; !ok {
+					t.Errorf("%s: unresolved $ref: %s", object.Name, schema.Ref)
+				}
+			}
+			for _, child := range schema.Properties {
+				checkRefs(child)
+			}
+			for _, child := range schema.Defs {
+				checkRefs(child)
+			}
+			for _, child := range schema.AnyOf {
+				checkRefs(child)
+			}
+			checkRefs(schema.Items)
+			checkRefs(schema.AdditionalProperties)
+		}
+		checkRefs(&schema)
+	}
+
+	b, err := def.JSONSchema("WelcomeRequest")
+	is.NoErr(err)
+	var welcomeRequest jsonSchema
+	is.NoErr(json.Unmarshal(b, &welcomeRequest))
+	is.Equal(welcomeRequest.Required, []string{"recipients", "times"}) // not the pointers
+	is.Equal(welcomeRequest.Properties["customerDetails"].AnyOf[0].Ref, "#/$defs/CustomerDetails")
+	is.Equal(len(welcomeRequest.Defs), 1)
+	is.Equal(welcomeRequest.Defs["CustomerDetails"].Required, []string{"newCustomer"})
+
+	b, err = def.JSONSchema("GetGreetingsResponse")
+	is.NoErr(err)
+	var getGreetingsResponse jsonSchema
+	is.NoErr(json.Unmarshal(b, &getGreetingsResponse))
+	is.Equal(getGreetingsResponse.Required, []string{"greetings"}) // not the omitempty error
+	is.Equal(getGreetingsResponse.Properties["greetings"].Items.Ref, "#/$defs/Greeting")
+
+	_, err = def.JSONSchema("Missing")
+	is.Equal(err, ErrNotFound)
+}
+
+func TestJSONSchemaEnum(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/discriminators").Parse()
+	is.NoErr(err)
+	b, err := def.JSONSchema("Message")
+	is.NoErr(err)
+	var message jsonSchema
+	is.NoErr(json.Unmarshal(b, &message))
+	is.Equal(message.Properties["type"].Enum, []interface{}{"text", "image"})
+	is.Equal(message.Properties["sender"].Enum, nil)
+}
+
+func TestJSONSchemaRecursive(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/recursive").Parse()
+	is.NoErr(err)
+	b, err := def.JSONSchema("Comment")
+	is.NoErr(err)
+	var comment jsonSchema
+	is.NoErr(json.Unmarshal(b, &comment))
+	_, ok := comment.Defs["Comment"]
+	is.True(ok) // refers to itself
+}
+
+func TestJSONSchemaNullable(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/nullable"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	b, err := def.JSONSchemaBundle()
+	is.NoErr(err)
+	var bundle struct {
+		Defs map[string]*jsonSchema `json:"$defs"`
+	}
+	is.NoErr(json.Unmarshal(b, &bundle))
+	properties := bundle.Defs["UpdateRequest"].Properties
+	is.Equal(properties["name"].Type, jsonSchemaType{"string"})
+	is.Equal(properties["nickname"].Type, jsonSchemaType{"string", "null"}) // *string
+	is.Equal(properties["nickname"].Description, "Nickname is the nickname, or null to remove it.")
+	is.Equal(properties["age"].Type, jsonSchemaType{"integer", "null"}) // nullable: true
+	is.Equal(len(properties["address"].AnyOf), 2)                       // *Address
+	is.Equal(properties["address"].AnyOf[0].Ref, "#/$defs/Address")
+	is.Equal(properties["address"].AnyOf[1].Type, jsonSchemaType{"null"})
+	is.Equal(properties["pets"].Type, jsonSchemaType{"array"}) // the list itself is not nullable
+	is.Equal(len(properties["pets"].Items.AnyOf), 2)           // but its items are
+	is.Equal(properties["pets"].Items.AnyOf[0].Ref, "#/$defs/Pet")
+}
+
+func TestJSONSchemaIntegers(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/streaming").Parse()
+	is.NoErr(err)
+	b, err := def.JSONSchema("PriceChange")
+	is.NoErr(err)
+	var priceChange jsonSchema
+	is.NoErr(json.Unmarshal(b, &priceChange))
+	is.Equal(priceChange.Properties["price"].Type, jsonSchemaType{"number"}) // float64
+	trade := priceChange.Defs["Trade"]
+	is.True(trade != nil)
+	is.Equal(trade.Properties["quantity"].Type, jsonSchemaType{"integer"}) // int
+	volumes := priceChange.Properties["volumes"].AdditionalProperties
+	is.Equal(volumes.Items.Type, jsonSchemaType{"integer"}) // map[string][]int
+}
+
+func TestJSONSchemaEnumTypes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/enums").Parse()
+	is.NoErr(err)
+	b, err := def.JSONSchema("UpdateTaskRequest")
+	is.NoErr(err)
+	var request jsonSchema
+	is.NoErr(json.Unmarshal(b, &request))
+	status := request.Properties["status"]
+	is.Equal(status.Type, jsonSchemaType{"string"})
+	is.Equal(status.Enum, []interface{}{"active", "archived"}) // imported
+	priority := request.Properties["priority"]
+	is.Equal(priority.Type, jsonSchemaType{"integer"})
+	is.Equal(priority.Enum, []interface{}{float64(1), float64(2)})
+	color := request.Properties["color"]
+	is.Equal(color.Type, jsonSchemaType{"string"})
+	is.Equal(len(color.Enum), 2)
+	is.Equal(request.Properties["label"].Enum, nil) // not an enum
+	estimates := request.Properties["estimates"].AdditionalProperties
+	is.Equal(estimates.Type, jsonSchemaType{"array"})
+	is.Equal(estimates.Items.Type, jsonSchemaType{"integer"})
+	is.Equal(estimates.Items.Enum, []interface{}{float64(0), float64(1)})
+}
+
+func TestJSONSchemaTimes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/times").Parse()
+	is.NoErr(err)
+	b, err := def.JSONSchema("CreateEventRequest")
+	is.NoErr(err)
+	var request jsonSchema
+	is.NoErr(json.Unmarshal(b, &request))
+	startsAt := request.Properties["startsAt"]
+	is.Equal(startsAt.Type, jsonSchemaType{"string"})
+	is.Equal(startsAt.Format, "date-time")
+	is.Equal(request.Properties["day"].Format, "date") // format: "date"
+	endsAt := request.Properties["endsAt"]
+	is.Equal(endsAt.Type, jsonSchemaType{"string", "null"}) // *time.Time
+	is.Equal(endsAt.Format, "date-time")
+	_, ok := request.Defs["Time"]
+	is.True(!ok) // times are not objects
+}
+
+func TestJSONSchemaTypeMarshalJSON(t *testing.T) {
+	is := is.New(t)
+	b, err := json.Marshal(jsonSchemaType{"string"})
+	is.NoErr(err)
+	is.Equal(string(b), `"string"`)
+	b, err = json.Marshal(jsonSchemaType{"string", "null"})
+	is.NoErr(err)
+	is.Equal(string(b), `["string","null"]`)
+}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// parseLite parses the syntax of the files specified, without
+// type checking.
+// Services, methods, objects and fields get their names, comments
+// and metadata. Types only get TypeName, ObjectName, CleanObjectName
+// and Multiple, taken from the source as written.
+func (p *Parser) parseLite() (Definition, error) {
+	// without NeedTypes, packages does not set pkg.Fset, and without
+	// NeedFiles it does not parse pkg.Syntax
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+		Fset:  fset,
+		Tests: false,
+	}
+	pkgs, err := packages.Load(cfg, p.patterns...)
+	if err != nil {
+		return p.def, err
+	}
+	for _, pkg := range pkgs {
+		p.docs, err = doc.NewFromFiles(fset, pkg.Syntax, "", doc.PreserveAST)
+		if err != nil {
+			return p.def, errors.Wrap(err, "read docs")
+		}
+		p.def.PackageName = pkg.Name
+		for _, typ := range p.docs.Types {
+			spec, ok := typ.Decl.Specs[0].(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			switch specType := spec.Type.(type) {
+			case *ast.InterfaceType:
+				if isInSlice(p.ExcludeInterfaces, typ.Name) {
+					continue
+				}
+				service, err := p.parseLiteService(typ.Name, specType)
+				if err != nil {
+					return p.def, err
+				}
+				p.def.Services = append(p.def.Services, service)
+			case *ast.StructType:
+				object, err := p.parseLiteObject(typ.Name, specType)
+				if err != nil {
+					return p.def, err
+				}
+				p.def.Objects = append(p.def.Objects, object)
+			}
+		}
+	}
+	sort.Slice(p.def.Services, func(i, j int) bool {
+		return p.def.Services[i].Name < p.def.Services[j].Name
+	})
+	sort.Slice(p.def.Objects, func(i, j int) bool {
+		return p.def.Objects[i].Name < p.def.Objects[j].Name
+	})
+	return p.def, nil
+}
+
+func (p *Parser) parseLiteService(name string, iface *ast.InterfaceType) (Service, error) {
+	service := Service{Name: name}
+	var err error
+	service.Metadata, service.Comment, err = p.extractCommentMetadata(p.commentForType(name))
+	if err != nil {
+		return service, errors.Wrap(err, name)
+	}
+	for _, m := range iface.Methods.List {
+		funcType, ok := m.Type.(*ast.FuncType)
+		if !ok || len(m.Names) == 0 {
+			continue
+		}
+		method := Method{
+			Name:            m.Names[0].Name,
+			NameLowerCamel:  camelizeDown(m.Names[0].Name),
+			NameUpperCamel:  camelizeUp(m.Names[0].Name),
+			NameTransformed: p.transformMethodName(m.Names[0].Name),
+		}
+		method.Metadata, method.Comment, err = p.extractCommentMetadata(p.commentForMethod(name, method.Name))
+		if err != nil {
+			return service, errors.Wrap(err, name+"."+method.Name)
+		}
+		if funcType.Params != nil && len(funcType.Params.List) > 0 {
+			params := funcType.Params.List
+			if len(params) == 2 && types.ExprString(params[0].Type) == "context.Context" {
+				method.TakesContext = true
+			}
+			method.InputObject = liteFieldType(params[len(params)-1].Type)
+		}
+		if funcType.Results != nil && len(funcType.Results.List) > 0 {
+			method.OutputObject = liteFieldType(funcType.Results.List[0].Type)
+		}
+		if funcType.Results != nil && len(funcType.Results.List) == 2 {
+			// without type checking, assume the second result is an error
+			method.ErrorType = types.ExprString(funcType.Results.List[1].Type)
+			method.ReturnsError = true
+		}
+		service.Methods = append(service.Methods, method)
+	}
+	sort.Slice(service.Methods, func(i, j int) bool {
+		return service.Methods[i].Name < service.Methods[j].Name
+	})
+	return service, nil
+}
+
+func (p *Parser) parseLiteObject(name string, structType *ast.StructType) (Object, error) {
+	object := Object{
+		Name:           name,
+		NameUpperCamel: camelizeUp(name),
+		BaseName:       name,
+		Fields:         []Field{},
+	}
+	var err error
+	object.Metadata, object.Comment, err = p.extractCommentMetadata(p.commentForType(name))
+	if err != nil {
+		return object, errors.Wrap(err, name)
+	}
+	for _, f := range structType.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			tag = strings.Trim(f.Tag.Value, "`")
+		}
+		for _, fieldName := range f.Names {
+			field := Field{
+				Name:           fieldName.Name,
+				NameLowerCamel: camelizeDown(fieldName.Name),
+				NameUpperCamel: camelizeUp(fieldName.Name),
+				ObjectName:     name,
+				Tag:            tag,
+				Type:           liteFieldType(f.Type),
+			}
+			if jsonTag := reflect.StructTag(tag).Get("json"); jsonTag != "" {
+				field.NameLowerCamel = strings.Split(jsonTag, ",")[0]
+			}
+			field.Metadata, field.Comment, err = p.extractCommentMetadata(p.commentForField(name, field.Name))
+			if err != nil {
+				return object, errors.Wrap(err, name+"."+field.Name)
+			}
+			object.Fields = append(object.Fields, field)
+		}
+	}
+	return object, nil
+}
+
+// liteFieldType gets the FieldType for the type expression, as
+// it is written in the source.
+func liteFieldType(expr ast.Expr) FieldType {
+	var ftype FieldType
+	if array, ok := expr.(*ast.ArrayType); ok && array.Len == nil {
+		ftype.Multiple = true
+		expr = array.Elt
+	}
+	ftype.TypeName = types.ExprString(expr)
+	pointer := false
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+		pointer = true
+	}
+	if selector, ok := expr.(*ast.SelectorExpr); ok {
+		// drop the package qualifier
+		expr = selector.Sel
+	}
+	ftype.CleanObjectName = types.ExprString(expr)
+	ftype.ObjectName = ftype.CleanObjectName
+	if pointer {
+		ftype.ObjectName = "*" + ftype.CleanObjectName
+	}
+	return ftype
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseLite(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	parser.Lite = true
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	is.Equal(def.PackageName, "pleasantries")
+	is.Equal(len(def.Services), 3)
+	greeter := def.Services[0]
+	is.Equal(greeter.Name, "GreeterService")
+	is.Equal(greeter.Comment, "GreeterService is a polite API.\nYou will love it.")
+	is.Equal(greeter.Metadata["strapline"], "A lovely greeter service")
+	is.Equal(len(greeter.Methods), 2)
+	is.Equal(greeter.Methods[0].Name, "GetGreetings")
+	is.Equal(greeter.Methods[1].Name, "Greet")
+	is.Equal(greeter.Methods[1].Comment, "Greet creates a Greeting for one or more people.")
+	is.Equal(greeter.Methods[1].InputObject.CleanObjectName, "GreetRequest")
+	is.Equal(greeter.Methods[1].OutputObject.CleanObjectName, "GreetResponse")
+
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	is.Equal(greetResponse.Fields[0].Name, "Greeting")
+	is.Equal(greetResponse.Fields[0].Comment, "Greeting is the greeted person's Greeting.")
+	is.Equal(greetResponse.Fields[0].Type.TypeName, "*Greeting")
+	is.Equal(greetResponse.Fields[0].Type.CleanObjectName, "Greeting")
+	is.Equal(greetResponse.Fields[0].Type.IsObject, false) // types are not resolved
+
+	getGreetingsRequest, err := def.Object("GetGreetingsRequest")
+	is.NoErr(err)
+	is.Equal(getGreetingsRequest.Fields[0].Type.TypeName, "services.Page")
+	is.Equal(getGreetingsRequest.Fields[0].Type.CleanObjectName, "Page")
+	_, err = def.Object("Page")
+	is.Equal(err, ErrNotFound) // imported packages are not loaded
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Markdown generates a human readable API reference in Markdown,
+// with a section for each Service and a subsection for each Method
+// describing its route, and the request and response objects.
+// Oto methods are always POST requests, and basePath is where the
+// services are served, like /oto.
+func (d *Definition) Markdown(basePath string) (string, error) {
+	basePath = strings.TrimSuffix(basePath, "/")
+	var buf bytes.Buffer
+	for _, service := range d.Services {
+		fmt.Fprintf(&buf, "## %s\n\n", service.Name)
+		if service.Comment != "" {
+			fmt.Fprintf(&buf, "%s\n\n", service.Comment)
+		}
+		for _, method := range service.Methods {
+			fmt.Fprintf(&buf, "### %s.%s\n\n", service.Name, method.Name)
+			fmt.Fprintf(&buf, "`POST %s/%s.%s`\n\n", basePath, service.Name, method.Name)
+			if method.Comment != "" {
+				fmt.Fprintf(&buf, "%s\n\n", method.Comment)
+			}
+			if err := d.writeMarkdownObject(&buf, "Request", method.InputObject.CleanObjectName); err != nil {
+				return "", errors.Wrapf(err, "%s.%s", service.Name, method.Name)
+			}
+			if err := d.writeMarkdownObject(&buf, "Response", method.OutputObject.CleanObjectName); err != nil {
+				return "", errors.Wrapf(err, "%s.%s", service.Name, method.Name)
+			}
+		}
+	}
+	return buf.String(), nil
+}
+
+// writeMarkdownObject writes a field table and an example JSON body
+// for the named object.
+func (d *Definition) writeMarkdownObject(buf *bytes.Buffer, heading, objectName string) error {
+	object, err := d.Object(objectName)
+	if err != nil {
+		return errors.Wrap(err, objectName)
+	}
+	fmt.Fprintf(buf, "#### %s: %s\n\n", heading, object.Name)
+	if object.Comment != "" {
+		fmt.Fprintf(buf, "%s\n\n", object.Comment)
+	}
+	fields := object.FieldsIn("markdown")
+	if len(fields) > 0 {
+		fmt.Fprintln(buf, "| Field | Type | Description |")
+		fmt.Fprintln(buf, "| --- | --- | --- |")
+		for _, field := range fields {
+			fmt.Fprintf(buf, "| `%s` | `%s` | %s |\n", field.NameLowerCamel, markdownTypeName(field.Type), markdownCell(fieldDescription(field)))
+		}
+		fmt.Fprintln(buf)
+	}
+	example, err := d.exampleIn(*object, "markdown")
+	if err != nil {
+		return errors.Wrap(err, "example")
+	}
+	b, err := json.MarshalIndent(example, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "example")
+	}
+	fmt.Fprintf(buf, "```json\n%s\n```\n\n", b)
+	return nil
+}
+
+// markdownTypeName gets the JSON type name of ftype as it appears
+// in the Markdown reference.
+func markdownTypeName(ftype FieldType) string {
+	name := ftype.JSType
+	if ftype.IsObject {
+		name = ftype.CleanObjectName
+	}
+	if ftype.Multiple {
+		name += "[]"
+	}
+	return name
+}
+
+// fieldDescription gets the comment of the field, followed by a
+// note of the version it was added in if it has since metadata.
+func fieldDescription(field Field) string {
+	if field.Since == "" {
+		return field.Comment
+	}
+	note := "Since " + field.Since + "."
+	if field.Comment == "" {
+		return note
+	}
+	return field.Comment + " " + note
+}
+
+// markdownCell makes s safe to use inside a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMarkdown(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	md, err := def.Markdown("/api/")
+	is.NoErr(err)
+	for _, should := range []string{
+		"## GreeterService\n\nGreeterService is a polite API.\nYou will love it.\n\n",
+		"### GreeterService.Greet\n\n`POST /api/GreeterService.Greet`\n\n",
+		"#### Response: GreetResponse\n\n",
+		"| Field | Type | Description |\n| --- | --- | --- |\n| `greeting` | `Greeting` | Greeting is the greeted person's Greeting. |",
+		"| `error` | `string` | Error is string explaining what went wrong. Empty if everything was fine. |\n",
+		"```json\n{\n",
+	} {
+		if !strings.Contains(md, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestMarkdownCell(t *testing.T) {
+	is := is.New(t)
+	is.Equal(markdownCell("One | two\nthree"), `One \| two three`)
+}
+
+func TestMarkdownSince(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/since"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	updateRequest, err := def.Object("UpdateRequest")
+	is.NoErr(err)
+	is.Equal(updateRequest.Fields[0].Name, "Name")
+	is.Equal(updateRequest.Fields[0].Since, "")
+	is.Equal(updateRequest.Fields[1].Name, "Pronouns")
+	is.Equal(updateRequest.Fields[1].Since, "v1.2")
+	is.Equal(updateRequest.Fields[1].Comment, "Pronouns are the preferred pronouns.")
+
+	md, err := def.Markdown("/oto")
+	is.NoErr(err)
+	for _, should := range []string{
+		"| `name` | `string` | Name is the display name. |\n",
+		"| `pronouns` | `string` | Pronouns are the preferred pronouns. Since v1.2. |\n",
+		"| `version` | `number` | Since v1.3. |\n",
+	} {
+		if !strings.Contains(md, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
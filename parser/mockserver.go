@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// TypeScriptMockServer generates TypeScript source code for an
+// Express router with a handler for each method, which responds with
+// an example of the output object.
+// Useful for frontend development without a real server.
+// Mount the router at the client's basepath (/oto/ by default).
+func (d *Definition) TypeScriptMockServer() (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by oto; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "import { Router } from 'express';")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "export const router = Router();")
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			example, err := d.mockResponse(method)
+			if err != nil {
+				return "", errors.Wrapf(err, "%s.%s", service.Name, method.Name)
+			}
+			fmt.Fprintln(&buf)
+			fmt.Fprintf(&buf, "// %s.%s responds with an example %s.\n", service.Name, method.Name, method.OutputObject.CleanObjectName)
+			fmt.Fprintf(&buf, "router.post('/%s.%s', (req, res) => {\n", service.Name, method.Name)
+			fmt.Fprintf(&buf, "\tres.json(%s);\n", example)
+			fmt.Fprintln(&buf, "});")
+		}
+	}
+	return buf.String(), nil
+}
+
+// mockResponse gets the example JSON for the output object of
+// the method, without OutputOnly fields like Error, so clients
+// treat it as a successful response, or fields excluded from
+// typescript.
+func (d *Definition) mockResponse(method Method) ([]byte, error) {
+	object, err := d.Object(method.OutputObject.CleanObjectName)
+	if err != nil {
+		return nil, err
+	}
+	response := *object
+	response.Fields = response.InputFields()
+	example, err := d.exampleIn(response, "typescript")
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(example, "\t", "\t")
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTypeScriptMockServer(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	src, err := def.TypeScriptMockServer()
+	is.NoErr(err)
+	for _, should := range []string{
+		"import { Router } from 'express';\n",
+		"// GreeterService.Greet responds with an example GreetResponse.\nrouter.post('/GreeterService.Greet', (req, res) => {\n\tres.json({\n\t\t\"greeting\": {\n\t\t\t\"text\": \"Hello there\"\n\t\t}\n\t});\n});\n",
+		"router.post('/GreeterService.GetGreetings', (req, res) => {\n",
+	} {
+		if !strings.Contains(src, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+	is.True(!strings.Contains(src, "something went wrong")) // no error field
+}
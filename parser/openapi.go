@@ -0,0 +1,227 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// OpenAPIOptions are options for generating OpenAPI documents.
+type OpenAPIOptions struct {
+	// Title is the title of the API. Defaults to the package name.
+	Title string
+	// Version is the version of the API. Defaults to 0.1.0.
+	Version string
+	// Description describes the API.
+	Description string
+	// ServerURL is the URL of the server hosting the API, which
+	// the paths are relative to. No servers are listed if empty.
+	ServerURL string
+}
+
+// OpenAPI generates an OpenAPI 3.1 document (as JSON) describing
+// the services.
+// Each method becomes a POST operation at /Service.Method, with
+// the input object as the request body and the output object as
+// the 200 response. Every object is described in components/schemas,
+// with since metadata noted in the field descriptions.
+// Operation ids come from OperationID.
+// Metadata with keys that begin with x- become vendor extensions on
+// the operation or schema, and the featured method metadata becomes
+// the x-featured extension.
+// Objects with unknown_keys: strict metadata do not allow
+// additional properties.
+func (d *Definition) OpenAPI(opts OpenAPIOptions) ([]byte, error) {
+	doc := openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title:       opts.Title,
+			Version:     opts.Version,
+			Description: opts.Description,
+		},
+		Paths: make(map[string]openAPIPathItem),
+		Components: openAPIComponents{
+			Schemas: make(map[string]*jsonSchema),
+		},
+	}
+	if doc.Info.Title == "" {
+		doc.Info.Title = d.PackageName
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "0.1.0"
+	}
+	if opts.ServerURL != "" {
+		doc.Servers = []openAPIServer{{URL: opts.ServerURL}}
+	}
+	for _, object := range d.Objects {
+		schema := d.jsonSchemaForObject(object, "openapi", openAPISchemaRefPrefix)
+		for _, field := range object.FieldsIn("openapi") {
+			schema.Properties[field.NameLowerCamel].Description = fieldDescription(field)
+		}
+		if object.UnknownKeys == "strict" {
+			schema.AdditionalProperties = &jsonSchema{False: true}
+		}
+		schema.Extensions = VendorExtensions(object.Metadata)
+		doc.Components.Schemas[object.Name] = schema
+	}
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			name := service.Name + "." + method.Name
+			for _, objectName := range []string{method.InputObject.CleanObjectName, method.OutputObject.CleanObjectName} {
+				if _, ok := doc.Components.Schemas[objectName]; !ok {
+					return nil, errors.Wrap(ErrNotFound, name+": "+objectName)
+				}
+			}
+			operationID, err := d.OperationID(service, method)
+			if err != nil {
+				return nil, err
+			}
+			extensions := VendorExtensions(method.Metadata)
+			if featured, ok := method.Metadata["featured"]; ok && extensions["x-featured"] == nil {
+				if extensions == nil {
+					extensions = make(map[string]interface{})
+				}
+				extensions["x-featured"] = featured
+			}
+			operation := &openAPIOperation{
+				OperationID: operationID,
+				Description: method.Comment,
+				Tags:        []string{service.Name},
+				Extensions:  extensions,
+				RequestBody: openAPIRequestBody{
+					Required: true,
+					Content:  openAPIContent(method.RequestContentType, method.BinaryRequest, method.InputObject.CleanObjectName),
+				},
+				Responses: map[string]openAPIResponse{
+					"200": {
+						Description: "A successful response.",
+						Content:     openAPIContent(method.ResponseContentType, method.BinaryResponse, method.OutputObject.CleanObjectName),
+					},
+				},
+			}
+			doc.Paths["/"+name] = openAPIPathItem{Post: operation}
+		}
+	}
+	b, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal")
+	}
+	return b, nil
+}
+
+// openAPIContent gets the content of a request or response body,
+// which is either binary data or a reference to the object.
+func openAPIContent(contentType string, binary bool, objectName string) map[string]openAPIMediaType {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	schema := &jsonSchema{Ref: openAPISchemaRefPrefix + objectName}
+	if binary {
+		schema = &jsonSchema{Type: jsonSchemaType{"string"}, ContentMediaType: contentType}
+	}
+	return map[string]openAPIMediaType{
+		contentType: {Schema: schema},
+	}
+}
+
+// openAPISchemaRefPrefix is the prefix of references to schemas in
+// OpenAPI documents.
+const openAPISchemaRefPrefix = "#/components/schemas/"
+
+type openAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       openAPIInfo                `json:"info"`
+	Servers    []openAPIServer            `json:"servers,omitempty"`
+	Paths      map[string]openAPIPathItem `json:"paths"`
+	Components openAPIComponents          `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIPathItem struct {
+	Post *openAPIOperation `json:"post"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	RequestBody openAPIRequestBody         `json:"requestBody"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	// Extensions are the vendor extensions, like x-featured.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON writes the operation with its Extensions.
+func (o *openAPIOperation) MarshalJSON() ([]byte, error) {
+	type operation openAPIOperation
+	return marshalWithExtensions((*operation)(o), o.Extensions)
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*jsonSchema `json:"schemas"`
+}
+
+// VendorExtensions gets the metadata whose keys begin with x-,
+// for use as vendor extensions in OpenAPI documents.
+// Returns nil if there are none.
+func VendorExtensions(metadata map[string]interface{}) map[string]interface{} {
+	var extensions map[string]interface{}
+	for key, value := range metadata {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if extensions == nil {
+			extensions = make(map[string]interface{})
+		}
+		extensions[key] = value
+	}
+	return extensions
+}
+
+// marshalWithExtensions marshals the struct v as a JSON object,
+// adding the extensions as extra keys.
+// Keys of v take precedence over extensions with the same name.
+func marshalWithExtensions(v interface{}, extensions map[string]interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return b, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(b, &object); err != nil {
+		return nil, err
+	}
+	for key, value := range extensions {
+		if _, ok := object[key]; ok {
+			continue
+		}
+		object[key], err = json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrap(err, key)
+		}
+	}
+	return json.Marshal(object)
+}
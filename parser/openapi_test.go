@@ -0,0 +1,222 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/pkg/errors"
+)
+
+// openAPITestDocument is the subset of an OpenAPI document that
+// the tests check.
+type openAPITestDocument struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths map[string]struct {
+		Post struct {
+			OperationID string      `json:"operationId"`
+			Description string      `json:"description"`
+			XFeatured   interface{} `json:"x-featured"`
+			RequestBody struct {
+				Required bool                        `json:"required"`
+				Content  map[string]openAPIMediaType `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Description string                      `json:"description"`
+				Content     map[string]openAPIMediaType `json:"content"`
+			} `json:"responses"`
+		} `json:"post"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]*jsonSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	b, err := def.OpenAPI(OpenAPIOptions{
+		Version:   "1.2.0",
+		ServerURL: "https://example.com/oto/",
+	})
+	is.NoErr(err)
+	var doc openAPITestDocument
+	is.NoErr(json.Unmarshal(b, &doc))
+	is.Equal(doc.OpenAPI, "3.1.0")
+	is.Equal(doc.Info.Title, "pleasantries") // defaults to the package name
+	is.Equal(doc.Info.Version, "1.2.0")
+	is.Equal(len(doc.Servers), 1)
+	is.Equal(doc.Servers[0].URL, "https://example.com/oto/")
+
+	greet, ok := doc.Paths["/GreeterService.Greet"]
+	is.True(ok)
+	is.Equal(greet.Post.OperationID, "greeterServiceGreet")
+	is.Equal(greet.Post.Description, "Greet creates a Greeting for one or more people.")
+	is.Equal(greet.Post.XFeatured, true)
+	is.True(greet.Post.RequestBody.Required)
+	is.Equal(greet.Post.RequestBody.Content["application/json"].Schema.Ref, "#/components/schemas/GreetRequest")
+	is.Equal(greet.Post.Responses["200"].Content["application/json"].Schema.Ref, "#/components/schemas/GreetResponse")
+	is.True(greet.Post.Responses["200"].Description != "") // required
+	getGreetings, ok := doc.Paths["/GreeterService.GetGreetings"]
+	is.True(ok)
+	is.Equal(getGreetings.Post.XFeatured, false)
+
+	is.Equal(len(doc.Components.Schemas), len(def.Objects))
+	greetRequest, ok := doc.Components.Schemas["GreetRequest"]
+	is.True(ok)
+	is.Equal(greetRequest.Description, "GreetRequest is the request object for GreeterService.Greet.")
+	is.Equal(greetRequest.Properties["names"].Description, "Names are the names of the people to greet.")
+	is.Equal(greetRequest.Properties["names"].Type, jsonSchemaType{"array"})
+	greetResponse, ok := doc.Components.Schemas["GreetResponse"]
+	is.True(ok)
+	is.Equal(greetResponse.Properties["greeting"].AnyOf[0].Ref, "#/components/schemas/Greeting")
+	_, ok = doc.Components.Schemas["Greeting"]
+	is.True(ok)
+}
+
+func TestOpenAPIVendorExtensions(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/vendorextensions").Parse()
+	is.NoErr(err)
+	b, err := def.OpenAPI(OpenAPIOptions{})
+	is.NoErr(err)
+	var doc struct {
+		Paths map[string]struct {
+			Post map[string]interface{} `json:"post"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	is.NoErr(json.Unmarshal(b, &doc))
+	placeOrder := doc.Paths["/OrderService.PlaceOrder"].Post
+	is.Equal(placeOrder["operationId"], "orderServicePlaceOrder")
+	is.Equal(placeOrder["x-internal-id"], float64(42))
+	is.Equal(placeOrder["x-rate-limit"], map[string]interface{}{"perMinute": float64(10)})
+	_, ok := placeOrder["x-featured"]
+	is.True(!ok) // no featured metadata
+	placeOrderRequest := doc.Components.Schemas["PlaceOrderRequest"]
+	is.Equal(placeOrderRequest["x-entity"], "order")
+	is.Equal(placeOrderRequest["type"], "object")
+	_, ok = doc.Components.Schemas["PlaceOrderResponse"]["x-entity"]
+	is.True(!ok)
+}
+
+func TestVendorExtensions(t *testing.T) {
+	is := is.New(t)
+	extensions := VendorExtensions(map[string]interface{}{
+		"x-rate-limit": 10,
+		"featured":     true,
+	})
+	is.Equal(extensions, map[string]interface{}{"x-rate-limit": 10})
+	is.Equal(VendorExtensions(map[string]interface{}{"featured": true}), nil)
+}
+
+func TestOpenAPIUnknownKeys(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/unknownkeys").Parse()
+	is.NoErr(err)
+	b, err := def.OpenAPI(OpenAPIOptions{})
+	is.NoErr(err)
+	var doc openAPITestDocument
+	is.NoErr(json.Unmarshal(b, &doc))
+	saveRequest := doc.Components.Schemas["SaveRequest"]
+	is.True(saveRequest.AdditionalProperties != nil)
+	is.Equal(saveRequest.AdditionalProperties.False, true)                 // strict
+	is.Equal(doc.Components.Schemas["Settings"].AdditionalProperties, nil) // passthrough
+	is.Equal(doc.Components.Schemas["SaveResponse"].AdditionalProperties, nil)
+
+	b, err = json.Marshal(saveRequest)
+	is.NoErr(err)
+	var raw map[string]interface{}
+	is.NoErr(json.Unmarshal(b, &raw))
+	is.Equal(raw["additionalProperties"], false)
+}
+
+func TestOpenAPIErrors(t *testing.T) {
+	is := is.New(t)
+	def := &Definition{
+		Services: []Service{
+			{
+				Name: "GreeterService",
+				Methods: []Method{
+					{
+						Name:         "Greet",
+						InputObject:  FieldType{CleanObjectName: "GreetRequest"},
+						OutputObject: FieldType{CleanObjectName: "GreetResponse"},
+					},
+				},
+			},
+		},
+	}
+	_, err := def.OpenAPI(OpenAPIOptions{})
+	is.True(err != nil)
+	is.True(errors.Is(err, ErrNotFound))
+	is.Equal(err.Error(), "GreeterService.Greet: GreetRequest: not found")
+
+	def.Objects = []Object{{Name: "GreetRequest"}, {Name: "GreetResponse"}}
+	def.Services[0].Methods = append(def.Services[0].Methods, Method{
+		Name:         "Hello",
+		Metadata:     map[string]interface{}{"operation_id": "greeterServiceGreet"},
+		InputObject:  FieldType{CleanObjectName: "GreetRequest"},
+		OutputObject: FieldType{CleanObjectName: "GreetResponse"},
+	})
+	_, err = def.OpenAPI(OpenAPIOptions{})
+	is.True(err != nil) // duplicate operation id
+}
+
+func TestOpenAPIBinary(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/binary"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	b, err := def.OpenAPI(OpenAPIOptions{Title: "Files"})
+	is.NoErr(err)
+	var doc openAPITestDocument
+	is.NoErr(json.Unmarshal(b, &doc))
+	is.Equal(doc.Info.Title, "Files")
+	is.Equal(doc.Info.Version, "0.1.0")
+	is.Equal(len(doc.Servers), 0)
+
+	download := doc.Paths["/FileService.Download"].Post
+	is.Equal(download.RequestBody.Content["application/json"].Schema.Ref, "#/components/schemas/DownloadRequest")
+	response := download.Responses["200"].Content["application/octet-stream"].Schema
+	is.Equal(response.Type, jsonSchemaType{"string"})
+	is.Equal(response.ContentMediaType, "application/octet-stream")
+	upload := doc.Paths["/FileService.Upload"].Post
+	is.Equal(upload.RequestBody.Content["application/octet-stream"].Schema.ContentMediaType, "application/octet-stream")
+}
+
+func TestOpenAPISince(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/since"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	b, err := def.OpenAPI(OpenAPIOptions{})
+	is.NoErr(err)
+	var doc openAPITestDocument
+	is.NoErr(json.Unmarshal(b, &doc))
+	updateRequest := doc.Components.Schemas["UpdateRequest"]
+	is.Equal(updateRequest.Properties["name"].Description, "Name is the display name.")
+	is.Equal(updateRequest.Properties["pronouns"].Description, "Pronouns are the preferred pronouns. Since v1.2.")
+	is.Equal(doc.Components.Schemas["UpdateResponse"].Properties["version"].Description, "Since v1.3.")
+}
@@ -0,0 +1,45 @@
+package parser
+
+import "github.com/pkg/errors"
+
+// OperationID gets the operation id for the method, which is the
+// service and method names in camel case (greeterServiceGreet),
+// or the operation_id metadata if set.
+// Generators for OpenAPI and AsyncAPI use it, so ids match.
+// Operation ids must be unique, so an error is returned if another
+// method in d has the same one.
+func (d *Definition) OperationID(service Service, method Method) (string, error) {
+	id, err := methodOperationID(service, method)
+	if err != nil {
+		return "", err
+	}
+	for _, otherService := range d.Services {
+		for _, otherMethod := range otherService.Methods {
+			if otherService.Name == service.Name && otherMethod.Name == method.Name {
+				continue
+			}
+			otherID, err := methodOperationID(otherService, otherMethod)
+			if err != nil {
+				return "", err
+			}
+			if otherID == id {
+				return "", errors.Errorf("%s.%s: operation id %q is also used by %s.%s", service.Name, method.Name, id, otherService.Name, otherMethod.Name)
+			}
+		}
+	}
+	return id, nil
+}
+
+// methodOperationID gets the operation id for the method, without
+// checking it is unique.
+func methodOperationID(service Service, method Method) (string, error) {
+	val, ok := method.Metadata["operation_id"]
+	if !ok {
+		return camelizeDown(service.Name) + method.Name, nil
+	}
+	id, ok := val.(string)
+	if !ok {
+		return "", errors.Errorf("%s.%s: operation_id: expected string, got %T", service.Name, method.Name, val)
+	}
+	return id, nil
+}
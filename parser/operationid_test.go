@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestOperationID(t *testing.T) {
+	is := is.New(t)
+	greeter := Service{
+		Name: "GreeterService",
+		Methods: []Method{
+			{Name: "Greet"},
+			{Name: "Farewell", Metadata: map[string]interface{}{"operation_id": "sayGoodbye"}},
+		},
+	}
+	def := &Definition{Services: []Service{greeter}}
+	id, err := def.OperationID(greeter, greeter.Methods[0])
+	is.NoErr(err)
+	is.Equal(id, "greeterServiceGreet")
+	id, err = def.OperationID(greeter, greeter.Methods[1])
+	is.NoErr(err)
+	is.Equal(id, "sayGoodbye") // operation_id metadata
+
+	greeter.Methods[1].Metadata["operation_id"] = "greeterServiceGreet"
+	_, err = def.OperationID(greeter, greeter.Methods[0])
+	is.True(err != nil) // duplicate
+	is.Equal(err.Error(), `GreeterService.Greet: operation id "greeterServiceGreet" is also used by GreeterService.Farewell`)
+	greeter.Methods[1].Metadata["operation_id"] = true
+	_, err = def.OperationID(greeter, greeter.Methods[0])
+	is.True(err != nil) // not a string
+}
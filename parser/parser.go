@@ -8,10 +8,15 @@ import (
 	"go/doc"
 	"go/token"
 	"go/types"
+	"math"
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/fatih/structtag"
 	"github.com/pkg/errors"
@@ -29,9 +34,19 @@ type Definition struct {
 	Services []Service `json:"services"`
 	// Objects are the structures that are used throughout this definition.
 	Objects []Object `json:"objects"`
+	// Enums are the named types with constant values that are
+	// used by fields.
+	Enums []Enum `json:"enums"`
 	// Imports is a map of Go imports that should be imported into
 	// Go code.
 	Imports map[string]string `json:"imports"`
+	// NameCollisions maps object names to the TypeIDs of each
+	// distinct type that shares that name.
+	// Only the first of these types is present in Objects.
+	NameCollisions map[string][]string `json:"nameCollisions,omitempty"`
+	// Acronyms are the extra acronyms to keep together when
+	// changing the case of names, taken from Parser.Acronyms.
+	Acronyms []string `json:"acronyms,omitempty"`
 }
 
 // Object looks up an object by name. Returns ErrNotFound error
@@ -76,6 +91,59 @@ func (d *Definition) ObjectIsOutput(name string) bool {
 	return false
 }
 
+// UsesMaps gets whether any field in the definition is a map, for
+// generators that need extra imports or runtime support for maps.
+func (d *Definition) UsesMaps() bool {
+	for _, object := range d.Objects {
+		for _, field := range object.Fields {
+			if field.Type.IsMap() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UsesEnums gets whether any field in the definition is an enum,
+// or a map of enums, for generators that need extra imports or
+// runtime support for enums.
+func (d *Definition) UsesEnums() bool {
+	for _, object := range d.Objects {
+		for _, field := range object.Fields {
+			if field.Type.IsEnum {
+				return true
+			}
+			if field.Type.IsMap() {
+				if _, err := d.Enum(field.Type.Map.CleanElementType); err == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ErrorCodes gets the error codes that any method may return, sorted
+// and without duplicates, so generators can emit a shared enum.
+// See Method.ErrorCodes.
+func (d *Definition) ErrorCodes() []string {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			for _, code := range method.ErrorCodes {
+				if seen[code] {
+					continue
+				}
+				seen[code] = true
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 // Service describes a service, akin to an interface in Go.
 type Service struct {
 	Name    string   `json:"name"`
@@ -84,6 +152,10 @@ type Service struct {
 	// Metadata are typed key/value pairs extracted from the
 	// comments.
 	Metadata map[string]interface{} `json:"metadata"`
+	// Module is the name of the group this service belongs to,
+	// for generators that emit one file per module.
+	// Set with the module metadata.
+	Module string `json:"module"`
 }
 
 func (s Service) MethodsByMetadata(field string) []MethodGroup {
@@ -127,6 +199,133 @@ type Method struct {
 	// Metadata are typed key/value pairs extracted from the
 	// comments.
 	Metadata map[string]interface{} `json:"metadata"`
+	// NameUpperCamel is the Name in PascalCase, with acronyms in
+	// upper case, like GetUserID.
+	NameUpperCamel string `json:"nameUpperCamel"`
+	// NameTransformed is the Name as produced by
+	// Parser.MethodNameTransform. Same as Name if no transform is set.
+	NameTransformed string `json:"nameTransformed"`
+	// RequestContentType is the content type of the request body.
+	// Set with the requestContentType metadata.
+	// Default: application/json
+	RequestContentType string `json:"requestContentType"`
+	// ResponseContentType is the content type of the response body.
+	// Set with the responseContentType metadata.
+	// Default: application/json
+	ResponseContentType string `json:"responseContentType"`
+	// DefaultSort is the order list methods return items in when
+	// none is specified, like "created_at desc".
+	// Set with the default_sort metadata.
+	DefaultSort string `json:"defaultSort"`
+	// Streaming is true if the method returns a channel, sending
+	// many OutputObject values rather than one.
+	Streaming bool `json:"streaming"`
+	// StreamingDirection describes which side of the method streams,
+	// for targets like gRPC; one of none, client (the method takes
+	// a channel), server (the method returns a channel) or bidi
+	// (both).
+	// InputObject and OutputObject are the element types of the
+	// channels.
+	StreamingDirection string `json:"streamingDirection"`
+	// CacheTTL is how long responses from this method may be cached
+	// for. Zero means responses should not be cached.
+	// Set with the cache_ttl metadata, like "60s" or "5m".
+	CacheTTL time.Duration `json:"cacheTTL"`
+	// SLAMs is the expected latency of the method in milliseconds,
+	// for documentation. Zero if there is no SLA.
+	// Set with the sla_ms metadata.
+	SLAMs int `json:"slaMs,omitempty"`
+	// ErrorCodes are the codes of the errors this method may return,
+	// like NOT_FOUND.
+	// Set with the error_codes metadata.
+	ErrorCodes []string `json:"errorCodes,omitempty"`
+	// ErrorType is the type of the error the method returns, if it
+	// has a second result. It is error, or the name of a custom type
+	// that implements error, like *ValidationError.
+	// Empty if the method only returns the OutputObject.
+	ErrorType string `json:"errorType,omitempty"`
+	// ReturnsError is true if the method returns an error as its
+	// second result, like Greet(GreetRequest) (GreetResponse, error).
+	ReturnsError bool `json:"returnsError"`
+	// BinaryRequest is true if the request body is raw binary data,
+	// like a file upload, rather than the encoded InputObject.
+	// Set with the binary_request metadata.
+	BinaryRequest bool `json:"binaryRequest"`
+	// BinaryResponse is true if the response body is raw binary
+	// data, like a file download, rather than the encoded
+	// OutputObject.
+	// Set with the binary_response metadata.
+	BinaryResponse bool `json:"binaryResponse"`
+	// InputExample is an example request body, read from the JSON
+	// file named by the example_file metadata.
+	InputExample json.RawMessage `json:"inputExample,omitempty"`
+	// OutputExample is an example response body, read from the JSON
+	// file named by the output_example_file metadata.
+	OutputExample json.RawMessage `json:"outputExample,omitempty"`
+	// TakesContext is true if the method takes a context.Context
+	// before its input object, like
+	// Greet(context.Context, GreetRequest) GreetResponse.
+	TakesContext bool `json:"takesContext"`
+}
+
+// IsMutation gets whether this method changes state, as opposed to
+// only reading it.
+// An explicit mutation metadata boolean takes precedence. Otherwise
+// methods marked idempotent, or with an http_method metadata of GET,
+// HEAD or OPTIONS are queries.
+// All other methods are mutations, since they are POSTed.
+func (m Method) IsMutation() bool {
+	if mutation, ok := m.Metadata["mutation"].(bool); ok {
+		return mutation
+	}
+	if idempotent, ok := m.Metadata["idempotent"].(bool); ok && idempotent {
+		return false
+	}
+	if httpMethod, ok := m.Metadata["http_method"].(string); ok {
+		switch strings.ToUpper(httpMethod) {
+		case "GET", "HEAD", "OPTIONS":
+			return false
+		}
+	}
+	return true
+}
+
+// Mutations gets all methods that change state.
+// See Method.IsMutation.
+func (d *Definition) Mutations() []Method {
+	var methods []Method
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if method.IsMutation() {
+				methods = append(methods, method)
+			}
+		}
+	}
+	return methods
+}
+
+// Queries gets all methods that only read state.
+// See Method.IsMutation.
+func (d *Definition) Queries() []Method {
+	var methods []Method
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			if !method.IsMutation() {
+				methods = append(methods, method)
+			}
+		}
+	}
+	return methods
+}
+
+// ServicesByModule groups the services by their Module.
+// Services without a module are grouped under the empty string.
+func (d *Definition) ServicesByModule() map[string][]Service {
+	modules := make(map[string][]Service)
+	for _, service := range d.Services {
+		modules[service.Module] = append(modules[service.Module], service)
+	}
+	return modules
 }
 
 // Object describes a data structure that is part of this definition.
@@ -141,6 +340,72 @@ type Object struct {
 	// Metadata are typed key/value pairs extracted from the
 	// comments.
 	Metadata map[string]interface{} `json:"metadata"`
+	// NameUpperCamel is the Name in PascalCase, with acronyms in
+	// upper case, like UserID.
+	NameUpperCamel string `json:"nameUpperCamel"`
+	// BaseName is the user facing name of the object, as produced
+	// by Parser.ObjectNameTransform. Same as Name if no transform
+	// is set.
+	BaseName string `json:"baseName"`
+	// UnknownKeys describes how unknown keys in this object should
+	// be treated by validating generators; one of strip (the default),
+	// strict or passthrough.
+	// Set with the unknown_keys metadata.
+	UnknownKeys string `json:"unknownKeys"`
+	// Immutable is true for value objects whose fields should not
+	// change once they are created, so generators can mark them
+	// readonly.
+	// Set with the immutable metadata.
+	Immutable bool `json:"immutable"`
+	// UsedByServices are the names of the services with methods
+	// that take or return this object directly.
+	UsedByServices []string `json:"usedByServices"`
+	// IDField is the name of the field that identifies this object,
+	// set with id: true metadata on the field, or else the field
+	// called ID or Id. Empty if there is no such field.
+	IDField string `json:"idField"`
+
+	// estimatedJSONSize is set by Parse, see EstimatedJSONSize.
+	estimatedJSONSize int
+}
+
+// IsEffectivelyEmpty gets whether the object has no fields, other
+// than the Error field added to output objects.
+func (o Object) IsEffectivelyEmpty() bool {
+	for _, field := range o.Fields {
+		if !isErrorField(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// InputFields gets the fields of the object that are relevant when
+// it is used as the input to a method, leaving out OutputOnly
+// fields.
+func (o Object) InputFields() []Field {
+	fields := make([]Field, 0, len(o.Fields))
+	for _, field := range o.Fields {
+		if field.OutputOnly {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// FieldsIn gets the fields of the object that are not excluded from
+// the target generator.
+// See Field.IsExcludedIn.
+func (o Object) FieldsIn(target string) []Field {
+	fields := make([]Field, 0, len(o.Fields))
+	for _, field := range o.Fields {
+		if field.IsExcludedIn(target) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
 }
 
 // Field describes the field inside an Object.
@@ -156,6 +421,53 @@ type Field struct {
 	// Metadata are typed key/value pairs extracted from the
 	// comments.
 	Metadata map[string]interface{} `json:"metadata"`
+	// NameUpperCamel is the Name in PascalCase, with acronyms in
+	// upper case, like UserID.
+	NameUpperCamel string `json:"nameUpperCamel"`
+	// Aliases are alternative names that are accepted for this
+	// field, while NameLowerCamel is the name that is emitted.
+	// Set with the aliases metadata.
+	Aliases []string `json:"aliases"`
+	// ExcludeIn are the generator targets that should leave this
+	// field out, which are go (the Go client and examples),
+	// typescript (including the mock server), swift, dart, python,
+	// openapi, jsonschema, asyncapi, proto, graphql and markdown.
+	// Go server code always keeps every field.
+	// Set with the exclude_in metadata.
+	ExcludeIn []string `json:"excludeIn"`
+	// Transform is how generated parsers should transform the value
+	// of this field, like trim or lowercase.
+	// Set with the transform metadata.
+	Transform string `json:"transform,omitempty"`
+	// DeprecatedBy is the name of the field that replaces this
+	// deprecated one.
+	// Set with the deprecated_by metadata.
+	DeprecatedBy string `json:"deprecatedBy,omitempty"`
+	// Since is the version of the API that added this field, like
+	// v1.2.
+	// Set with the since metadata.
+	Since string `json:"since,omitempty"`
+	// ObjectName is the name of the Object this field belongs to.
+	ObjectName string `json:"objectName"`
+	// Optional is true for fields that may be missing because they
+	// come from an embedded pointer to a struct.
+	Optional bool `json:"optional"`
+	// OutputOnly is true for fields that only make sense in
+	// responses, like the Error field added to output objects.
+	// Objects can be both input and output, so generators should
+	// leave these fields out when the object is used as input.
+	// See Object.InputFields.
+	OutputOnly bool `json:"outputOnly"`
+}
+
+// IsExcludedIn gets whether this field should be left out of the
+// output for the target generator.
+// Fields with exclude: true metadata are excluded from all targets.
+func (f Field) IsExcludedIn(target string) bool {
+	if exclude, ok := f.Metadata["exclude"].(bool); ok && exclude {
+		return true
+	}
+	return isInSlice(f.ExcludeIn, target)
 }
 
 // FieldTag is a parsed tag.
@@ -184,7 +496,103 @@ type FieldType struct {
 	JSType               string `json:"jsType"`
 	TSType               string `json:"tsType"`
 	SwiftType            string `json:"swiftType"`
-	DartType             string `json:"dartType"`
+	// DartType is the complete Dart type for a field of this type,
+	// including List<...> for slices and ? for pointers, like
+	// List<Greeting?>. Pointer types are already nullable, so
+	// templates should only add ? to other optional fields.
+	DartType string `json:"dartType"`
+	// PythonType is the complete Python type hint for a field of
+	// this type, including List[...] for slices and Optional[...]
+	// for pointers, like List[Optional[Greeting]].
+	PythonType string `json:"pythonType"`
+	// RustType is the complete Rust type for a field of this type,
+	// including Vec<...> for slices and Option<...> for pointers,
+	// like Vec<Option<Greeting>>.
+	RustType string `json:"rustType"`
+	// KotlinType is the complete Kotlin type for a field of this
+	// type, including List<...> for slices and ? for pointers, like
+	// List<Greeting?>.
+	KotlinType string `json:"kotlinType"`
+	// CSharpType is the complete C# type for a field of this type,
+	// including List<...> for slices and ? for pointers to value
+	// types, like List<int?>.
+	CSharpType string `json:"csharpType"`
+	// SwiftTypeFull is the complete Swift type for a field of this
+	// type, including [] for slices and the optional marker; String?
+	// or Optional<String>, as chosen with the swift_optional
+	// metadata.
+	SwiftTypeFull string `json:"swiftTypeFull"`
+	// ElementIsPointer is true for slices of pointers, like
+	// []*Greeting, where the elements may be null.
+	ElementIsPointer bool `json:"elementIsPointer"`
+	// IsEnum is true if this type is one of the Definition.Enums,
+	// named by CleanObjectName.
+	IsEnum bool `json:"isEnum"`
+	// Map describes the key and element types for map types.
+	// Nil if this is not a map.
+	Map *FieldTypeMap `json:"map,omitempty"`
+	// AliasName is the name of the type alias (declared with =)
+	// that was used for this type, if any.
+	// The other fields describe the aliased type.
+	AliasName string `json:"aliasName,omitempty"`
+}
+
+// FieldTypeMap describes the key and element types of a map.
+// Maps of slices, like map[string][]int, have ElementIsMultiple set,
+// while slices of maps, like []map[string]int, are FieldTypes with
+// Multiple set and a Map.
+type FieldTypeMap struct {
+	// KeyType is the Go type of the keys.
+	KeyType string `json:"keyType"`
+	// CleanKeyType is KeyType without any package prefix.
+	CleanKeyType string `json:"cleanKeyType"`
+	// ElementType is the Go type of the values. For maps of slices,
+	// this is the type of the slice elements.
+	ElementType string `json:"elementType"`
+	// CleanElementType is ElementType without any package prefix
+	// or * for pointer types.
+	CleanElementType string `json:"cleanElementType"`
+	// ElementIsMultiple is true if the values are slices.
+	ElementIsMultiple bool `json:"elementIsMultiple"`
+	// ElementIsObject is true if the values are objects.
+	ElementIsObject   bool   `json:"elementIsObject"`
+	KeyTypeJS         string `json:"keyTypeJS"`
+	ElementTypeJS     string `json:"elementTypeJS"`
+	KeyTypeTS         string `json:"keyTypeTS"`
+	ElementTypeTS     string `json:"elementTypeTS"`
+	KeyTypeSwift      string `json:"keyTypeSwift"`
+	ElementTypeSwift  string `json:"elementTypeSwift"`
+	KeyTypeDart       string `json:"keyTypeDart"`
+	ElementTypeDart   string `json:"elementTypeDart"`
+	KeyTypePython     string `json:"keyTypePython"`
+	ElementTypePython string `json:"elementTypePython"`
+	KeyTypeRust       string `json:"keyTypeRust"`
+	ElementTypeRust   string `json:"elementTypeRust"`
+	KeyTypeKotlin     string `json:"keyTypeKotlin"`
+	ElementTypeKotlin string `json:"elementTypeKotlin"`
+	KeyTypeCSharp     string `json:"keyTypeCSharp"`
+	ElementTypeCSharp string `json:"elementTypeCSharp"`
+	// ElementIsEnum is true if the values are one of the
+	// Definition.Enums, named by CleanElementType.
+	ElementIsEnum bool `json:"elementIsEnum"`
+
+	// elementEnumBaseType is the underlying Go type of enum values,
+	// like string or int.
+	elementEnumBaseType string
+}
+
+// IsMap returns true for map types.
+func (f FieldType) IsMap() bool {
+	return f.Map != nil
+}
+
+// String gets the Go type expression for this type, for example
+// []*Greeting or map[string]int.
+func (f FieldType) String() string {
+	if f.Multiple {
+		return "[]" + f.TypeName
+	}
+	return f.TypeName
 }
 
 // IsOptional returns true for pointer types (optional).
@@ -198,6 +606,11 @@ type Parser struct {
 
 	ExcludeInterfaces []string
 
+	// IgnoreImports are package paths that should not be added
+	// to Definition.Imports.
+	// Default: context
+	IgnoreImports []string
+
 	PackageName string
 
 	patterns []string
@@ -205,12 +618,79 @@ type Parser struct {
 
 	// outputObjects marks output object names.
 	outputObjects map[string]struct{}
-	// objects marks object names.
-	objects map[string]struct{}
+	// objects maps object names to their TypeID.
+	objects map[string]string
+	// parsingObjects are the names of the objects whose fields are
+	// being parsed, so recursive types are only parsed once.
+	parsingObjects map[string]bool
 
 	// SuppressErrorField suppresses the Error field in output objects.
 	SuppressErrorField bool
 
+	// StrictTags makes parsing fail if a json tag has an option that
+	// encoding/json does not recognize, or the same option twice.
+	// Otherwise they are reported in Verbose mode.
+	StrictTags bool
+
+	// FlattenEmbedded promotes the fields of embedded structs into
+	// the objects that embed them, like encoding/json does. If false,
+	// embedded structs are fields named after their type.
+	// Default: true
+	FlattenEmbedded bool
+
+	// ErrorObject is the name of a struct in the package to use for
+	// the Error field in output objects, in place of a string. For
+	// example, an ErrorResponse with Code, Message and Details fields.
+	ErrorObject string
+
+	// ExampleDir is the directory that example_file and
+	// output_example_file paths are relative to.
+	// Default: the current directory
+	ExampleDir string
+
+	// RequireComments makes parsing fail if any service, method,
+	// object, or field is missing a comment.
+	// Comments for objects from other packages are not checked.
+	RequireComments bool
+
+	// ExampleFunc, if set, is consulted for each field before the
+	// built-in example logic. If it returns true, the returned value
+	// is used as the example for the field.
+	ExampleFunc func(field Field) (interface{}, bool)
+
+	// TimeExample is the example used for time.Time fields that
+	// don't specify one.
+	// Default: 2021-01-02T15:04:05Z
+	TimeExample string
+	// DateExample is the example used for time.Time fields with
+	// format: "date" metadata that don't specify one.
+	// Default: 2021-01-02
+	DateExample string
+
+	// ObjectNameTransform, if set, produces Object.BaseName from
+	// the name of each object.
+	// For example, BaseObjectName strips the Request and Response
+	// suffixes.
+	ObjectNameTransform func(name string) string
+
+	// MethodNameTransform, if set, produces Method.NameTransformed
+	// from the name of each method.
+	// For example, StripPrefixes("Get") turns GetGreetings into
+	// Greetings.
+	MethodNameTransform func(name string) string
+
+	// Lite makes Parse only read the syntax of the source files,
+	// without resolving types or loading dependencies. This is much
+	// faster, and enough to list services, methods and objects with
+	// their comments, but type details will be incomplete.
+	// See parseLite.
+	Lite bool
+
+	// Acronyms are extra acronyms, like OAuth or GraphQL, to keep
+	// together as one word when templates change the case of names.
+	// They are available to templates as Definition.Acronyms.
+	Acronyms []string
+
 	// docs are the docs for extracting comments.
 	docs *doc.Package
 }
@@ -220,14 +700,20 @@ type Parser struct {
 // and will be passed to the underlying build system.
 func New(patterns ...string) *Parser {
 	return &Parser{
-		patterns: patterns,
+		patterns:        patterns,
+		IgnoreImports:   []string{"context"},
+		FlattenEmbedded: true,
 	}
 }
 
 // Parse parses the files specified, returning the definition.
 func (p *Parser) Parse() (Definition, error) {
+	p.def.Acronyms = p.Acronyms
+	if p.Lite {
+		return p.parseLite()
+	}
 	cfg := &packages.Config{
-		Mode:  packages.NeedTypes | packages.NeedName | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedName | packages.NeedSyntax,
+		Mode:  packages.NeedTypes | packages.NeedName | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports | packages.NeedSyntax,
 		Tests: false,
 	}
 	pkgs, err := packages.Load(cfg, p.patterns...)
@@ -235,10 +721,11 @@ func (p *Parser) Parse() (Definition, error) {
 		return p.def, err
 	}
 	p.outputObjects = make(map[string]struct{})
-	p.objects = make(map[string]struct{})
+	p.objects = make(map[string]string)
+	p.parsingObjects = make(map[string]bool)
 	var excludedObjectsTypeIDs []string
 	for _, pkg := range pkgs {
-		p.docs, err = doc.NewFromFiles(pkg.Fset, pkg.Syntax, "")
+		p.docs, err = doc.NewFromFiles(pkg.Fset, pkg.Syntax, "", doc.PreserveAST)
 		if err != nil {
 			panic(err)
 		}
@@ -246,6 +733,10 @@ func (p *Parser) Parse() (Definition, error) {
 		scope := pkg.Types.Scope()
 		for _, name := range scope.Names() {
 			obj := scope.Lookup(name)
+			if typeName, ok := obj.(*types.TypeName); ok && typeName.IsAlias() {
+				// aliases resolve to the types they alias
+				continue
+			}
 			switch item := obj.Type().Underlying().(type) {
 			case *types.Interface:
 				s, err := p.parseService(pkg, obj, item)
@@ -265,11 +756,22 @@ func (p *Parser) Parse() (Definition, error) {
 			}
 		}
 	}
+	// objects used by services that are not excluded are kept
+	usedTypeIDs := make(map[string]bool)
+	for _, service := range p.def.Services {
+		for _, method := range service.Methods {
+			usedTypeIDs[method.InputObject.TypeID] = true
+			usedTypeIDs[method.OutputObject.TypeID] = true
+		}
+	}
 	// remove any excluded objects
 	nonExcludedObjects := make([]Object, 0, len(p.def.Objects))
 	for _, object := range p.def.Objects {
 		excluded := false
 		for _, excludedTypeID := range excludedObjectsTypeIDs {
+			if usedTypeIDs[excludedTypeID] {
+				continue
+			}
 			if object.TypeID == excludedTypeID {
 				excluded = true
 				break
@@ -289,14 +791,38 @@ func (p *Parser) Parse() (Definition, error) {
 	sort.Slice(p.def.Objects, func(i, j int) bool {
 		return p.def.Objects[i].Name < p.def.Objects[j].Name
 	})
+	// sort enums
+	sort.Slice(p.def.Enums, func(i, j int) bool {
+		return p.def.Enums[i].Name < p.def.Enums[j].Name
+	})
+	p.addUsedByServices()
 	if !p.SuppressErrorField {
 		if err := p.addOutputFields(); err != nil {
 			return p.def, err
 		}
 	}
+	p.addEstimatedJSONSizes()
 	return p.def, nil
 }
 
+// addUsedByServices sets Object.UsedByServices for the input and
+// output objects of each method.
+func (p *Parser) addUsedByServices() {
+	for _, service := range p.def.Services {
+		for _, method := range service.Methods {
+			for _, typeName := range []string{method.InputObject.CleanObjectName, method.OutputObject.CleanObjectName} {
+				obj, err := p.def.Object(typeName)
+				if err != nil {
+					continue
+				}
+				if !isInSlice(obj.UsedByServices, service.Name) {
+					obj.UsedByServices = append(obj.UsedByServices, service.Name)
+				}
+			}
+		}
+	}
+}
+
 func (p *Parser) parseService(pkg *packages.Package, obj types.Object, interfaceType *types.Interface) (Service, error) {
 	var s Service
 	s.Name = obj.Name()
@@ -306,13 +832,21 @@ func (p *Parser) parseService(pkg *packages.Package, obj types.Object, interface
 	if err != nil {
 		return s, p.wrapErr(errors.New("extract comment metadata"), pkg, obj.Pos())
 	}
+	if p.RequireComments && s.Comment == "" {
+		return s, p.wrapErr(errors.New(s.Name+" must have a comment"), pkg, obj.Pos())
+	}
+	s.Module, err = metadataString(s.Metadata, "module", "")
+	if err != nil {
+		return s, p.wrapErr(err, pkg, obj.Pos())
+	}
 	if p.Verbose {
 		fmt.Printf("%s ", s.Name)
 	}
 	l := interfaceType.NumMethods()
 	for i := 0; i < l; i++ {
 		m := interfaceType.Method(i)
-		method, err := p.parseMethod(pkg, s.Name, m)
+		embeddedIn, _ := embeddedInterfaceName(interfaceType, m.Name())
+		method, err := p.parseMethod(pkg, s, embeddedIn, m)
 		if err != nil {
 			return s, err
 		}
@@ -321,30 +855,173 @@ func (p *Parser) parseService(pkg *packages.Package, obj types.Object, interface
 	return s, nil
 }
 
-func (p *Parser) parseMethod(pkg *packages.Package, serviceName string, methodType *types.Func) (Method, error) {
+// embeddedInterfaceName gets the name of the interface embedded in
+// iface (directly or not) that declares the method.
+// Returns false if iface declares the method itself.
+func embeddedInterfaceName(iface *types.Interface, method string) (string, bool) {
+	for i := 0; i < iface.NumExplicitMethods(); i++ {
+		if iface.ExplicitMethod(i).Name() == method {
+			return "", false
+		}
+	}
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		named, ok := iface.EmbeddedType(i).(*types.Named)
+		if !ok {
+			continue
+		}
+		embedded, ok := named.Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+		if name, ok := embeddedInterfaceName(embedded, method); ok {
+			return name, true
+		}
+		for j := 0; j < embedded.NumExplicitMethods(); j++ {
+			if embedded.ExplicitMethod(j).Name() == method {
+				return named.Obj().Name(), true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseMethod parses a method of the service.
+// Methods from embedded interfaces (embeddedIn is the name of the
+// interface that declares it) take their comments from that
+// interface, and inherit any service metadata they do not set
+// themselves.
+func (p *Parser) parseMethod(pkg *packages.Package, service Service, embeddedIn string, methodType *types.Func) (Method, error) {
+	serviceName := service.Name
 	var m Method
 	m.Name = methodType.Name()
 	m.NameLowerCamel = camelizeDown(m.Name)
-	m.Comment = p.commentForMethod(serviceName, m.Name)
+	m.NameUpperCamel = camelizeUp(m.Name)
+	m.NameTransformed = p.transformMethodName(m.Name)
+	if embeddedIn != "" {
+		m.Comment = p.commentForMethod(embeddedIn, m.Name)
+	} else {
+		m.Comment = p.commentForMethod(serviceName, m.Name)
+	}
 	var err error
 	m.Metadata, m.Comment, err = p.extractCommentMetadata(m.Comment)
 	if err != nil {
 		return m, p.wrapErr(errors.New("extract comment metadata"), pkg, methodType.Pos())
 	}
+	if embeddedIn != "" {
+		for key, value := range service.Metadata {
+			if _, ok := m.Metadata[key]; !ok {
+				m.Metadata[key] = value
+			}
+		}
+	}
+	if p.RequireComments && m.Comment == "" {
+		return m, p.wrapErr(errors.New(serviceName+"."+m.Name+" must have a comment"), pkg, methodType.Pos())
+	}
+	m.BinaryRequest, err = metadataBool(m.Metadata, "binary_request", false)
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	m.BinaryResponse, err = metadataBool(m.Metadata, "binary_response", false)
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	requestContentType, responseContentType := "application/json", "application/json"
+	if m.BinaryRequest {
+		requestContentType = "application/octet-stream"
+	}
+	if m.BinaryResponse {
+		responseContentType = "application/octet-stream"
+	}
+	m.RequestContentType, err = metadataString(m.Metadata, "requestContentType", requestContentType)
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	m.ResponseContentType, err = metadataString(m.Metadata, "responseContentType", responseContentType)
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	m.DefaultSort, err = metadataString(m.Metadata, "default_sort", "")
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	cacheTTL, err := metadataString(m.Metadata, "cache_ttl", "")
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	if cacheTTL != "" {
+		m.CacheTTL, err = time.ParseDuration(cacheTTL)
+		if err != nil {
+			return m, p.wrapErr(errors.Wrap(err, "cache_ttl"), pkg, methodType.Pos())
+		}
+		if m.CacheTTL < 0 {
+			return m, p.wrapErr(errors.Errorf("cache_ttl: must not be negative, got %s", cacheTTL), pkg, methodType.Pos())
+		}
+	}
+	m.SLAMs, err = MetadataInt(m.Metadata, "sla_ms", 0)
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	if m.SLAMs < 0 {
+		return m, p.wrapErr(errors.Errorf("sla_ms: must not be negative, got %d", m.SLAMs), pkg, methodType.Pos())
+	}
+	m.ErrorCodes, err = metadataStrings(m.Metadata, "error_codes")
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	m.InputExample, err = p.readExampleFile(m.Metadata, "example_file")
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
+	m.OutputExample, err = p.readExampleFile(m.Metadata, "output_example_file")
+	if err != nil {
+		return m, p.wrapErr(err, pkg, methodType.Pos())
+	}
 	sig := methodType.Type().(*types.Signature)
 	inputParams := sig.Params()
-	if inputParams.Len() != 1 {
+	if inputParams.Len() == 2 && isContextType(inputParams.At(0).Type()) {
+		m.TakesContext = true
+	}
+	if inputParams.Len() < 1 || inputParams.Len() > 2 || (inputParams.Len() == 2 && !m.TakesContext) {
 		return m, p.wrapErr(errors.New("invalid method signature: expected Method(MethodRequest) MethodResponse"), pkg, methodType.Pos())
 	}
-	m.InputObject, err = p.parseFieldType(pkg, inputParams.At(0))
+	input := inputParams.At(inputParams.Len() - 1)
+	clientStreaming := false
+	if ch, ok := input.Type().(*types.Chan); ok {
+		clientStreaming = true
+		input = types.NewVar(input.Pos(), input.Pkg(), input.Name(), ch.Elem())
+	}
+	m.InputObject, err = p.parseFieldType(pkg, input)
 	if err != nil {
 		return m, errors.Wrap(err, "parse input object type")
 	}
 	outputParams := sig.Results()
-	if outputParams.Len() != 1 {
+	if outputParams.Len() < 1 || outputParams.Len() > 2 {
 		return m, p.wrapErr(errors.New("invalid method signature: expected Method(MethodRequest) MethodResponse"), pkg, methodType.Pos())
 	}
-	m.OutputObject, err = p.parseFieldType(pkg, outputParams.At(0))
+	if outputParams.Len() == 2 {
+		errType := outputParams.At(1).Type()
+		if !types.Implements(errType, errorInterface) {
+			return m, p.wrapErr(errors.New("invalid method signature: expected Method(MethodRequest) (MethodResponse, error)"), pkg, methodType.Pos())
+		}
+		m.ErrorType = types.TypeString(errType, types.RelativeTo(pkg.Types))
+		m.ReturnsError = true
+	}
+	output := outputParams.At(0)
+	if ch, ok := output.Type().(*types.Chan); ok {
+		m.Streaming = true
+		output = types.NewVar(output.Pos(), output.Pkg(), output.Name(), ch.Elem())
+	}
+	switch {
+	case clientStreaming && m.Streaming:
+		m.StreamingDirection = "bidi"
+	case clientStreaming:
+		m.StreamingDirection = "client"
+	case m.Streaming:
+		m.StreamingDirection = "server"
+	default:
+		m.StreamingDirection = "none"
+	}
+	m.OutputObject, err = p.parseFieldType(pkg, output)
 	if err != nil {
 		return m, errors.Wrap(err, "parse output object type")
 	}
@@ -356,45 +1033,300 @@ func (p *Parser) parseMethod(pkg *packages.Package, serviceName string, methodTy
 func (p *Parser) parseObject(pkg *packages.Package, o types.Object, v *types.Struct) error {
 	var obj Object
 	obj.Name = o.Name()
+	obj.NameUpperCamel = camelizeUp(obj.Name)
 	obj.Comment = p.commentForType(obj.Name)
 	var err error
 	obj.Metadata, obj.Comment, err = p.extractCommentMetadata(obj.Comment)
 	if err != nil {
 		return p.wrapErr(errors.New("extract comment metadata"), pkg, o.Pos())
 	}
-	if _, found := p.objects[obj.Name]; found {
+	typeID := o.Pkg().Path() + "." + obj.Name
+	if existingTypeID, found := p.objects[obj.Name]; found {
 		// if this has already been parsed, skip it
+		if existingTypeID != typeID {
+			p.addNameCollision(obj.Name, existingTypeID, typeID)
+		}
+		return nil
+	}
+	if p.parsingObjects[obj.Name] {
 		return nil
 	}
 	if o.Pkg().Name() != pkg.Name {
 		obj.Imported = true
 	}
+	if p.RequireComments && !obj.Imported && obj.Comment == "" {
+		return p.wrapErr(errors.New(obj.Name+" must have a comment"), pkg, o.Pos())
+	}
 	typ := v.Underlying()
 	st, ok := typ.(*types.Struct)
 	if !ok {
 		return p.wrapErr(errors.New(obj.Name+" must be a struct"), pkg, o.Pos())
 	}
-	obj.TypeID = o.Pkg().Path() + "." + obj.Name
+	obj.TypeID = typeID
+	obj.UnknownKeys, err = metadataString(obj.Metadata, "unknown_keys", "strip")
+	if err != nil {
+		return p.wrapErr(err, pkg, o.Pos())
+	}
+	switch obj.UnknownKeys {
+	case "strip", "strict", "passthrough":
+	default:
+		return p.wrapErr(errors.New(obj.Name+": unknown_keys must be strip, strict or passthrough"), pkg, o.Pos())
+	}
+	obj.Immutable, err = metadataBool(obj.Metadata, "immutable", false)
+	if err != nil {
+		return p.wrapErr(errors.Wrap(err, obj.Name), pkg, o.Pos())
+	}
+	obj.BaseName = obj.Name
+	if p.ObjectNameTransform != nil {
+		obj.BaseName = p.ObjectNameTransform(obj.Name)
+	}
 
 	obj.ObjectName = types.TypeString(o.Type(), func(other *types.Package) string { return "" })
 	obj.ExternalObjectName = types.TypeString(o.Type(), func(other *types.Package) string { return p.PackageName })
 
-	obj.Fields = []Field{}
+	// recursive types (like Replies []*Comment) refer to objects
+	// that are still being parsed
+	p.parsingObjects[obj.Name] = true
+	obj.Fields, err = p.parseObjectFields(pkg, obj.Name, obj.Name, !obj.Imported, st, false, map[string]bool{})
+	delete(p.parsingObjects, obj.Name)
+	if err != nil {
+		return err
+	}
+	for i := range obj.Fields {
+		swiftOptional, err := swiftOptionalMode(obj.Metadata, obj.Fields[i].Metadata)
+		if err != nil {
+			return p.wrapErr(errors.Wrap(err, obj.Name+"."+obj.Fields[i].Name), pkg, o.Pos())
+		}
+		obj.Fields[i].Type.SwiftTypeFull = swiftTypeFull(obj.Fields[i].Type, swiftOptional)
+	}
+	obj.IDField, err = idField(obj.Fields)
+	if err != nil {
+		return p.wrapErr(errors.Wrap(err, obj.Name), pkg, o.Pos())
+	}
+	p.def.Objects = append(p.def.Objects, obj)
+	p.objects[obj.Name] = obj.TypeID
+	return nil
+}
+
+// parseObjectFields parses the fields of st, which belong to the
+// object called objectName.
+// Like encoding/json, fields of embedded structs (and pointers to
+// structs) are flattened into the object, unless FlattenEmbedded is
+// false. Fields from embedded pointers are Optional, since the
+// pointer may be nil.
+// When more than one field has the same name, encoding/json's rules
+// decide which is kept: the least deeply embedded field wins, and if
+// several are equally shallow, the one with a JSON name in its tag
+// wins. If that still leaves more than one, they are all dropped.
+// typeName is the name of the type declaring st, which is used to
+// look up comments.
+func (p *Parser) parseObjectFields(pkg *packages.Package, objectName, typeName string, checkComments bool, st *types.Struct, optional bool, seen map[string]bool) ([]Field, error) {
+	candidates, err := p.parseObjectFieldCandidates(pkg, objectName, typeName, checkComments, st, optional, 0, seen)
+	if err != nil {
+		return nil, err
+	}
+	return dominantFields(candidates), nil
+}
+
+// fieldCandidate is a field that may be promoted into an object,
+// and how deeply it is embedded.
+type fieldCandidate struct {
+	field Field
+	// name is the name used in JSON, which embedded fields compete
+	// for.
+	name string
+	// depth is how many embedded structs deep the field is, with
+	// fields declared on the object itself at depth zero.
+	depth int
+	// tagged is true if the field has a JSON name in its tag.
+	tagged bool
+}
+
+// parseObjectFieldCandidates parses the fields of st, and the fields
+// of any embedded structs, at the given depth.
+func (p *Parser) parseObjectFieldCandidates(pkg *packages.Package, objectName, typeName string, checkComments bool, st *types.Struct, optional bool, depth int, seen map[string]bool) ([]fieldCandidate, error) {
+	seen[typeName] = true
+	defer delete(seen, typeName)
+	var candidates []fieldCandidate
 	for i := 0; i < st.NumFields(); i++ {
-		field, err := p.parseField(pkg, obj.Name, st.Field(i), st.Tag(i))
+		if embedded, embeddedOptional, ok := embeddedStruct(st.Field(i), st.Tag(i)); ok && p.FlattenEmbedded {
+			embeddedName := embedded.Obj().Name()
+			if seen[embeddedName] {
+				continue
+			}
+			embeddedStruct := embedded.Underlying().(*types.Struct)
+			embeddedCheckComments := checkComments && embedded.Obj().Pkg().Path() == pkg.PkgPath
+			embeddedCandidates, err := p.parseObjectFieldCandidates(pkg, objectName, embeddedName, embeddedCheckComments, embeddedStruct, optional || embeddedOptional, depth+1, seen)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, embeddedCandidates...)
+			continue
+		}
+		field, err := p.parseField(pkg, typeName, st.Field(i), st.Tag(i))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		field.Tag = v.Tag(i)
+		field.ObjectName = objectName
+		if p.RequireComments && checkComments && field.Comment == "" {
+			return nil, p.wrapErr(errors.New(objectName+"."+field.Name+" must have a comment"), pkg, st.Field(i).Pos())
+		}
+		if optional {
+			field.Optional = true
+			field.OmitEmpty = true
+		}
+		field.Tag = st.Tag(i)
 		field.ParsedTags, err = p.parseTags(field.Tag)
 		if err != nil {
-			return errors.Wrap(err, "parse field tag")
+			return nil, p.wrapErr(errors.Wrap(err, "parse field tag: "+objectName+"."+field.Name), pkg, st.Field(i).Pos())
+		}
+		candidate := fieldCandidate{
+			field: field,
+			name:  field.Name,
+			depth: depth,
+		}
+		if jsonName := strings.Split(reflect.StructTag(field.Tag).Get("json"), ",")[0]; jsonName != "" {
+			candidate.name = jsonName
+			candidate.tagged = true
 		}
-		obj.Fields = append(obj.Fields, field)
+		candidates = append(candidates, candidate)
 	}
-	p.def.Objects = append(p.def.Objects, obj)
-	p.objects[obj.Name] = struct{}{}
-	return nil
+	return candidates, nil
+}
+
+// dominantFields gets the fields from candidates that win under
+// encoding/json's rules for fields with the same name, keeping
+// their order.
+func dominantFields(candidates []fieldCandidate) []Field {
+	byName := make(map[string][]int)
+	for i, candidate := range candidates {
+		byName[candidate.name] = append(byName[candidate.name], i)
+	}
+	fields := []Field{}
+	for i, candidate := range candidates {
+		if dominantField(candidates, byName[candidate.name]) == i {
+			fields = append(fields, candidate.field)
+		}
+	}
+	return fields
+}
+
+// dominantField gets the index of the candidate that wins out of
+// those at indexes, which all have the same name, or -1 if none do.
+func dominantField(candidates []fieldCandidate, indexes []int) int {
+	var shallowest []int
+	for _, i := range indexes {
+		if len(shallowest) > 0 && candidates[i].depth > candidates[shallowest[0]].depth {
+			continue
+		}
+		if len(shallowest) > 0 && candidates[i].depth < candidates[shallowest[0]].depth {
+			shallowest = shallowest[:0]
+		}
+		shallowest = append(shallowest, i)
+	}
+	if len(shallowest) == 1 {
+		return shallowest[0]
+	}
+	winner := -1
+	for _, i := range shallowest {
+		if !candidates[i].tagged {
+			continue
+		}
+		if winner != -1 {
+			return -1
+		}
+		winner = i
+	}
+	return winner
+}
+
+// embeddedStruct gets the named struct type of v if it is an embedded
+// struct, or pointer to a struct, that should be flattened.
+// Embedded fields with a JSON name are treated like any other field.
+func embeddedStruct(v *types.Var, tag string) (*types.Named, bool, bool) {
+	if !v.Anonymous() {
+		return nil, false, false
+	}
+	if jsonName := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]; jsonName != "" {
+		return nil, false, false
+	}
+	typ := v.Type()
+	pointer := false
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+		pointer = true
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || isTimeType(named) {
+		return nil, false, false
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, false, false
+	}
+	return named, pointer, true
+}
+
+// BaseObjectName strips the Request or Response suffix from name,
+// so GreetRequest becomes Greet.
+// It can be used as a Parser.ObjectNameTransform.
+func BaseObjectName(name string) string {
+	return StripSuffixes("Request", "Response")(name)
+}
+
+// StripSuffixes makes a Parser.ObjectNameTransform that removes the
+// first matching suffix from a name.
+// Names that are only a suffix are left unchanged.
+func StripSuffixes(suffixes ...string) func(name string) string {
+	return func(name string) string {
+		for _, suffix := range suffixes {
+			if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+				return strings.TrimSuffix(name, suffix)
+			}
+		}
+		return name
+	}
+}
+
+// StripPrefixes makes a Parser.MethodNameTransform that removes the
+// first matching prefix from a name.
+// Prefixes are only removed from the start of a word, so
+// StripPrefixes("Get") turns GetGreetings into Greetings but leaves
+// Getaway alone.
+func StripPrefixes(prefixes ...string) func(name string) string {
+	return func(name string) string {
+		for _, prefix := range prefixes {
+			if len(name) > len(prefix) && strings.HasPrefix(name, prefix) {
+				rest := strings.TrimPrefix(name, prefix)
+				if r, _ := utf8.DecodeRuneInString(rest); unicode.IsUpper(r) {
+					return rest
+				}
+			}
+		}
+		return name
+	}
+}
+
+// transformMethodName gets the name of the method after the
+// MethodNameTransform, if there is one.
+func (p *Parser) transformMethodName(name string) string {
+	if p.MethodNameTransform == nil {
+		return name
+	}
+	return p.MethodNameTransform(name)
+}
+
+// addNameCollision records that the distinct types identified by
+// typeIDs share the same object name.
+func (p *Parser) addNameCollision(name string, typeIDs ...string) {
+	if p.def.NameCollisions == nil {
+		p.def.NameCollisions = make(map[string][]string)
+	}
+	for _, typeID := range typeIDs {
+		if !isInSlice(p.def.NameCollisions[name], typeID) {
+			p.def.NameCollisions[name] = append(p.def.NameCollisions[name], typeID)
+		}
+	}
+	sort.Strings(p.def.NameCollisions[name])
 }
 
 func (p *Parser) parseTags(tag string) (map[string]FieldTag, error) {
@@ -409,13 +1341,45 @@ func (p *Parser) parseTags(tag string) (map[string]FieldTag, error) {
 			Options: tag.Options,
 		}
 	}
+	if jsonTag, ok := fieldTags["json"]; ok {
+		if err := checkJSONTagOptions(jsonTag.Options); err != nil {
+			if p.StrictTags {
+				return nil, err
+			}
+			if p.Verbose {
+				fmt.Printf("(warning) %s\n", err)
+			}
+		}
+	}
 	return fieldTags, nil
 }
 
+// jsonTagOptions are the json tag options that encoding/json
+// recognizes.
+var jsonTagOptions = []string{"omitempty", "omitzero", "string"}
+
+// checkJSONTagOptions returns an error if any of the options are not
+// recognized by encoding/json, or appear more than once.
+func checkJSONTagOptions(options []string) error {
+	seen := make(map[string]bool)
+	for _, option := range options {
+		if !isInSlice(jsonTagOptions, option) {
+			return errors.Errorf("json tag: unknown option %q", option)
+		}
+		if seen[option] {
+			return errors.Errorf("json tag: duplicate option %q", option)
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
 func (p *Parser) parseField(pkg *packages.Package, objectName string, v *types.Var, tag string) (Field, error) {
 	var f Field
 	f.Name = v.Name()
+	f.ObjectName = objectName
 	f.NameLowerCamel = camelizeDown(f.Name)
+	f.NameUpperCamel = camelizeUp(f.Name)
 	// if it has a json tag, use that as the NameJSON.
 	if tag != "" {
 		fieldTag := reflect.StructTag(tag)
@@ -430,17 +1394,66 @@ func (p *Parser) parseField(pkg *packages.Package, objectName string, v *types.V
 		return f, p.wrapErr(errors.New(f.Name+" must be exported"), pkg, v.Pos())
 	}
 	var err error
-	f.Metadata, f.Comment, err = p.extractCommentMetadata(f.Comment)
+	comment := f.Comment
+	f.Metadata, f.Comment, err = p.extractCommentMetadata(comment)
 	if err != nil {
 		return f, p.wrapErr(errors.New("extract comment metadata"), pkg, v.Pos())
 	}
-	if example, ok := f.Metadata["example"]; ok {
-		f.Example = example
+	if _, ok := f.Metadata["default"]; !ok {
+		if value, ok := constDefault(pkg, comment); ok {
+			f.Metadata["default"] = value
+		}
+	}
+	f.Aliases, err = metadataStrings(f.Metadata, "aliases")
+	if err != nil {
+		return f, p.wrapErr(err, pkg, v.Pos())
+	}
+	f.ExcludeIn, err = metadataStrings(f.Metadata, "exclude_in")
+	if err != nil {
+		return f, p.wrapErr(err, pkg, v.Pos())
+	}
+	f.Transform, err = metadataString(f.Metadata, "transform", "")
+	if err != nil {
+		return f, p.wrapErr(err, pkg, v.Pos())
+	}
+	f.DeprecatedBy, err = metadataString(f.Metadata, "deprecated_by", "")
+	if err != nil {
+		return f, p.wrapErr(err, pkg, v.Pos())
+	}
+	f.Since, err = metadataString(f.Metadata, "since", "")
+	if err != nil {
+		return f, p.wrapErr(err, pkg, v.Pos())
 	}
 	f.Type, err = p.parseFieldType(pkg, v)
 	if err != nil {
 		return f, errors.Wrap(err, "parse type")
 	}
+	if err := checkRange(f); err != nil {
+		return f, p.wrapErr(errors.Wrap(err, objectName+"."+f.Name), pkg, v.Pos())
+	}
+	if p.ExampleFunc != nil {
+		if example, ok := p.ExampleFunc(f); ok {
+			f.Example = example
+			return f, nil
+		}
+	}
+	if example, ok := f.Metadata["example"]; ok {
+		f.Example = example
+		return f, nil
+	}
+	if example, ok := reflect.StructTag(tag).Lookup("example"); ok {
+		f.Example, err = tagExample(f.Type, example)
+		if err != nil {
+			return f, p.wrapErr(errors.Wrap(err, "example tag"), pkg, v.Pos())
+		}
+		return f, nil
+	}
+	if isTimeFieldType(f.Type) {
+		f.Example = p.timeExample(f)
+	}
+	if example, ok := numberFieldExample(f); ok {
+		f.Example = example
+	}
 	return f, nil
 }
 
@@ -449,10 +1462,12 @@ func (p *Parser) parseFieldType(pkg *packages.Package, obj types.Object) (FieldT
 	pkgPath := pkg.PkgPath
 	resolver := func(other *types.Package) string {
 		if other.Name() != pkg.Name {
-			if p.def.Imports == nil {
-				p.def.Imports = make(map[string]string)
+			if !isInSlice(p.IgnoreImports, other.Path()) {
+				if p.def.Imports == nil {
+					p.def.Imports = make(map[string]string)
+				}
+				p.def.Imports[other.Path()] = other.Name()
 			}
-			p.def.Imports[other.Path()] = other.Name()
 			ftype.Package = other.Path()
 			pkgPath = other.Path()
 			return other.Name()
@@ -460,24 +1475,47 @@ func (p *Parser) parseFieldType(pkg *packages.Package, obj types.Object) (FieldT
 		return "" // no package prefix
 	}
 
-	typ := obj.Type()
-	if slice, ok := obj.Type().(*types.Slice); ok {
-		typ = slice.Elem()
+	typ := p.unalias(&ftype, obj.Type())
+	if slice, ok := typ.(*types.Slice); ok {
+		typ = p.unalias(&ftype, slice.Elem())
 		ftype.Multiple = true
 	}
 	isPointer := true
 	originalTyp := typ
 	pointerType, isPointer := typ.(*types.Pointer)
 	if isPointer {
-		typ = pointerType.Elem()
+		typ = p.unalias(&ftype, pointerType.Elem())
+		originalTyp = types.NewPointer(typ)
 		isPointer = true
+		ftype.ElementIsPointer = ftype.Multiple
+	}
+	if ftype.AliasName == "" {
+		ftype.AliasName = syntaxAliasName(pkg, obj)
+	}
+	isTime := isTimeType(typ)
+	if mapType, ok := typ.(*types.Map); ok {
+		var err error
+		ftype.Map, err = p.parseFieldTypeMap(pkg, mapType, resolver)
+		if err != nil {
+			return ftype, err
+		}
 	}
-	if named, ok := typ.(*types.Named); ok {
+	var enumBaseType string
+	if named, ok := typ.(*types.Named); ok && !isTime {
 		if structure, ok := named.Underlying().(*types.Struct); ok {
 			if err := p.parseObject(pkg, named.Obj(), structure); err != nil {
 				return ftype, err
 			}
 			ftype.IsObject = true
+		} else if basic, ok := named.Underlying().(*types.Basic); ok {
+			isEnum, err := p.parseEnum(pkg, named, basic)
+			if err != nil {
+				return ftype, err
+			}
+			if isEnum {
+				ftype.IsEnum = true
+				enumBaseType = basic.Name()
+			}
 		}
 	}
 	// disallow nested structs
@@ -495,48 +1533,572 @@ func (p *Parser) parseFieldType(pkg *packages.Package, obj types.Object) (FieldT
 	ftype.JSType = ftype.CleanObjectName
 	ftype.SwiftType = ftype.CleanObjectName
 	ftype.DartType = ftype.CleanObjectName
+	ftype.PythonType = ftype.CleanObjectName
+	ftype.RustType = ftype.CleanObjectName
+	ftype.KotlinType = ftype.CleanObjectName
+	ftype.CSharpType = camelizeUp(ftype.CleanObjectName)
 	if ftype.IsObject {
 		ftype.JSType = "object"
 		//ftype.SwiftType = "Any"
-	} else {
-		switch ftype.CleanObjectName {
-		case "interface{}":
-			ftype.JSType = "any"
-			ftype.SwiftType = "Any"
-			ftype.TSType = "object"
-			ftype.DartType = "dynamic"
-		case "map[string]interface{}":
-			ftype.JSType = "object"
-			ftype.TSType = "object"
-			ftype.SwiftType = "Any"
-			ftype.DartType = "Map<String, dynamic>"
-		case "string":
-			ftype.JSType = "string"
-			ftype.SwiftType = "String"
-			ftype.TSType = "string"
-			ftype.DartType = "String"
-		case "bool":
-			ftype.JSType = "boolean"
-			ftype.SwiftType = "Bool"
-			ftype.TSType = "boolean"
-			ftype.DartType = "bool"
-		case "int", "int16", "int32", "int64",
-			"uint", "uint16", "uint32", "uint64":
-			ftype.JSType = "number"
-			ftype.TSType = "number"
-			ftype.SwiftType = "Int"
-			ftype.DartType = "int"
-		case "float32", "float64":
-			ftype.JSType = "number"
-			ftype.SwiftType = "Double"
-			ftype.TSType = "number"
-			ftype.DartType = "double"
-		}
+	} else if isTime {
+		// times are strings on the wire
+		ftype.JSType = "string"
+		ftype.SwiftType = "String"
+		ftype.TSType = "string"
+		ftype.DartType = "String"
+		ftype.PythonType = "str"
+		ftype.RustType = "String"
+		ftype.KotlinType = "String"
+		ftype.CSharpType = "string"
+	} else if ftype.CleanObjectName == "map[string]interface{}" {
+		ftype.JSType = "object"
+		ftype.TSType = "object"
+		ftype.SwiftType = "Any"
+		ftype.DartType = "Map<String, dynamic>"
+		ftype.PythonType = "Dict[str, Any]"
+		ftype.RustType = "HashMap<String, serde_json::Value>"
+		ftype.KotlinType = "Map<String, Any>"
+		ftype.CSharpType = "Dictionary<string, object>"
+	} else if ftype.Map != nil {
+		key := ftype.Map.keyLanguageTypes()
+		elem := ftype.Map.elementLanguageTypes()
+		ftype.JSType = "object"
+		ftype.TSType = "Record<" + key.TS + ", " + elem.TS + ">"
+		ftype.SwiftType = "[" + key.Swift + ": " + elem.Swift + "]"
+		ftype.DartType = "Map<" + key.Dart + ", " + elem.Dart + ">"
+		ftype.PythonType = "Dict[" + key.Python + ", " + elem.Python + "]"
+		ftype.RustType = "HashMap<" + key.Rust + ", " + elem.Rust + ">"
+		ftype.KotlinType = "Map<" + key.Kotlin + ", " + elem.Kotlin + ">"
+		ftype.CSharpType = "Dictionary<" + key.CSharp + ", " + elem.CSharp + ">"
+	} else if names, ok := scalarLanguageTypes(enumBaseType); ok && ftype.IsEnum {
+		// enums are their base type on the wire
+		ftype.JSType = names.JS
+		ftype.TSType = names.TS
+		ftype.SwiftType = names.Swift
+		ftype.DartType = names.Dart
+		ftype.PythonType = names.Python
+		ftype.RustType = names.Rust
+		ftype.KotlinType = names.Kotlin
+		ftype.CSharpType = names.CSharp
+	} else if names, ok := scalarLanguageTypes(ftype.CleanObjectName); ok {
+		ftype.JSType = names.JS
+		ftype.TSType = names.TS
+		ftype.SwiftType = names.Swift
+		ftype.DartType = names.Dart
+		ftype.PythonType = names.Python
+		ftype.RustType = names.Rust
+		ftype.KotlinType = names.Kotlin
+		ftype.CSharpType = names.CSharp
+	}
+	if isPointer {
+		ftype.PythonType = "Optional[" + ftype.PythonType + "]"
+		ftype.RustType = "Option<" + ftype.RustType + ">"
+	}
+	if ftype.Multiple {
+		ftype.PythonType = "List[" + ftype.PythonType + "]"
+		ftype.RustType = "Vec<" + ftype.RustType + ">"
+	}
+	if ftype.IsOptional() {
+		ftype.KotlinType += "?"
+	}
+	if ftype.Multiple {
+		ftype.KotlinType = "List<" + ftype.KotlinType + ">"
+	}
+	if ftype.IsOptional() {
+		ftype.DartType += "?"
+	}
+	if ftype.Multiple {
+		ftype.DartType = "List<" + ftype.DartType + ">"
+	}
+	if ftype.IsOptional() && isInSlice(csharpValueTypes, ftype.CSharpType) {
+		// reference types are already nullable
+		ftype.CSharpType += "?"
+	}
+	if ftype.Multiple {
+		ftype.CSharpType = "List<" + ftype.CSharpType + ">"
 	}
 
 	return ftype, nil
 }
 
+// parseFieldTypeMap parses the key and element types of a map.
+func (p *Parser) parseFieldTypeMap(pkg *packages.Package, mapType *types.Map, resolver types.Qualifier) (*FieldTypeMap, error) {
+	var m FieldTypeMap
+	noPackage := func(other *types.Package) string { return "" }
+	m.KeyType = types.TypeString(mapType.Key(), resolver)
+	m.CleanKeyType = types.TypeString(mapType.Key(), noPackage)
+	elem := mapType.Elem()
+	if slice, ok := elem.(*types.Slice); ok {
+		elem = slice.Elem()
+		m.ElementIsMultiple = true
+	}
+	m.ElementType = types.TypeString(elem, resolver)
+	if pointer, ok := elem.(*types.Pointer); ok {
+		elem = pointer.Elem()
+	}
+	m.CleanElementType = types.TypeString(elem, noPackage)
+	if named, ok := elem.(*types.Named); ok && !isTimeType(elem) {
+		if structure, ok := named.Underlying().(*types.Struct); ok {
+			if err := p.parseObject(pkg, named.Obj(), structure); err != nil {
+				return nil, err
+			}
+			m.ElementIsObject = true
+		} else if basic, ok := named.Underlying().(*types.Basic); ok {
+			isEnum, err := p.parseEnum(pkg, named, basic)
+			if err != nil {
+				return nil, err
+			}
+			if isEnum {
+				m.ElementIsEnum = true
+				m.elementEnumBaseType = basic.Name()
+			}
+		}
+	}
+	key := m.keyLanguageTypes()
+	m.KeyTypeJS = key.JS
+	m.KeyTypeTS = key.TS
+	m.KeyTypeSwift = key.Swift
+	m.KeyTypeDart = key.Dart
+	m.KeyTypePython = key.Python
+	m.KeyTypeRust = key.Rust
+	m.KeyTypeKotlin = key.Kotlin
+	m.KeyTypeCSharp = key.CSharp
+	element := m.elementLanguageTypes()
+	m.ElementTypeJS = element.JS
+	m.ElementTypeTS = element.TS
+	m.ElementTypeSwift = element.Swift
+	m.ElementTypeDart = element.Dart
+	m.ElementTypePython = element.Python
+	m.ElementTypeRust = element.Rust
+	m.ElementTypeKotlin = element.Kotlin
+	m.ElementTypeCSharp = element.CSharp
+	return &m, nil
+}
+
+// keyLanguageTypes gets the languageTypes for the map keys.
+func (m *FieldTypeMap) keyLanguageTypes() languageTypes {
+	return languageTypesFor(m.CleanKeyType, false, false)
+}
+
+// elementLanguageTypes gets the languageTypes for the map values,
+// including multiplicity.
+// Enums are their base type on the wire.
+func (m *FieldTypeMap) elementLanguageTypes() languageTypes {
+	if m.ElementIsEnum {
+		return languageTypesFor(m.elementEnumBaseType, false, m.ElementIsMultiple)
+	}
+	return languageTypesFor(m.CleanElementType, m.ElementIsObject, m.ElementIsMultiple)
+}
+
+// languageTypes are the names of a type in each target language.
+type languageTypes struct {
+	JS     string
+	TS     string
+	Swift  string
+	Dart   string
+	Python string
+	Rust   string
+	Kotlin string
+	CSharp string
+}
+
+// languageTypesFor gets the languageTypes for the Go type name.
+// Objects and unknown types keep their Go name.
+func languageTypesFor(goType string, isObject, multiple bool) languageTypes {
+	names := languageTypes{
+		JS:     goType,
+		TS:     goType,
+		Swift:  goType,
+		Dart:   goType,
+		Python: goType,
+		Rust:   goType,
+		Kotlin: goType,
+		CSharp: camelizeUp(goType),
+	}
+	if isObject {
+		names.JS = "object"
+	} else if scalarNames, ok := scalarLanguageTypes(goType); ok {
+		names = scalarNames
+	}
+	if multiple {
+		names.JS = "array"
+		names.TS = names.TS + "[]"
+		names.Swift = "[" + names.Swift + "]"
+		names.Dart = "List<" + names.Dart + ">"
+		names.Python = "List[" + names.Python + "]"
+		names.Rust = "Vec<" + names.Rust + ">"
+		names.Kotlin = "List<" + names.Kotlin + ">"
+		names.CSharp = "List<" + names.CSharp + ">"
+	}
+	return names
+}
+
+// rustNumberTypes are the Rust types for each of the Go number types.
+var rustNumberTypes = map[string]string{
+	"int":     "i64",
+	"int8":    "i8",
+	"int16":   "i16",
+	"int32":   "i32",
+	"int64":   "i64",
+	"uint":    "u64",
+	"uint8":   "u8",
+	"uint16":  "u16",
+	"uint32":  "u32",
+	"uint64":  "u64",
+	"float32": "f32",
+	"float64": "f64",
+}
+
+// kotlinNumberTypes are the Kotlin types for each of the Go number
+// types.
+var kotlinNumberTypes = map[string]string{
+	"int":     "Long",
+	"int8":    "Int",
+	"int16":   "Int",
+	"int32":   "Int",
+	"int64":   "Long",
+	"uint":    "Long",
+	"uint8":   "Int",
+	"uint16":  "Int",
+	"uint32":  "Long",
+	"uint64":  "Long",
+	"float32": "Double",
+	"float64": "Double",
+}
+
+// csharpNumberTypes are the C# types for each of the Go number types.
+var csharpNumberTypes = map[string]string{
+	"int":     "long",
+	"int8":    "int",
+	"int16":   "int",
+	"int32":   "int",
+	"int64":   "long",
+	"uint":    "long",
+	"uint8":   "int",
+	"uint16":  "int",
+	"uint32":  "long",
+	"uint64":  "long",
+	"float32": "double",
+	"float64": "double",
+}
+
+// csharpValueTypes are the C# value types, which need a ? to be
+// nullable.
+var csharpValueTypes = []string{"int", "long", "double", "bool"}
+
+// scalarLanguageTypes gets the languageTypes for the Go type name.
+// Returns false if goType is not a known scalar type.
+func scalarLanguageTypes(goType string) (languageTypes, bool) {
+	switch goType {
+	case "interface{}":
+		return languageTypes{
+			JS:     "any",
+			TS:     "object",
+			Swift:  "Any",
+			Dart:   "dynamic",
+			Python: "Any",
+			Rust:   "serde_json::Value",
+			Kotlin: "Any",
+			CSharp: "object",
+		}, true
+	case "string":
+		return languageTypes{
+			JS:     "string",
+			TS:     "string",
+			Swift:  "String",
+			Dart:   "String",
+			Python: "str",
+			Rust:   "String",
+			Kotlin: "String",
+			CSharp: "string",
+		}, true
+	case "bool":
+		return languageTypes{
+			JS:     "boolean",
+			TS:     "boolean",
+			Swift:  "Bool",
+			Dart:   "bool",
+			Python: "bool",
+			Rust:   "bool",
+			Kotlin: "Boolean",
+			CSharp: "bool",
+		}, true
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return languageTypes{
+			JS:     "number",
+			TS:     "number",
+			Swift:  "Int",
+			Dart:   "int",
+			Python: "int",
+			Rust:   rustNumberTypes[goType],
+			Kotlin: kotlinNumberTypes[goType],
+			CSharp: csharpNumberTypes[goType],
+		}, true
+	case "float32", "float64":
+		return languageTypes{
+			JS:     "number",
+			TS:     "number",
+			Swift:  "Double",
+			Dart:   "double",
+			Python: "float",
+			Rust:   rustNumberTypes[goType],
+			Kotlin: kotlinNumberTypes[goType],
+			CSharp: csharpNumberTypes[goType],
+		}, true
+	}
+	return languageTypes{}, false
+}
+
+// unalias resolves typ to the type it aliases, recording the name
+// of the alias in ftype.AliasName.
+// Types that are not aliases are returned as-is.
+func (p *Parser) unalias(ftype *FieldType, typ types.Type) types.Type {
+	for {
+		alias, ok := typ.(aliasType)
+		if !ok || !alias.Obj().IsAlias() {
+			return typ
+		}
+		if ftype.AliasName == "" {
+			ftype.AliasName = alias.Obj().Name()
+		}
+		typ = alias.Rhs()
+	}
+}
+
+// syntaxAliasName gets the name of the type alias the field obj
+// was declared with, by looking at the syntax.
+// Without the gotypesalias GODEBUG setting, the type information has
+// no aliases, so unalias cannot record them.
+// Returns an empty string if obj was not declared with an alias.
+func syntaxAliasName(pkg *packages.Package, obj types.Object) string {
+	if pkg.TypesInfo == nil || !obj.Pos().IsValid() {
+		return ""
+	}
+	var expr ast.Expr
+	for _, file := range pkg.Syntax {
+		if obj.Pos() < file.Pos() || obj.Pos() > file.End() {
+			continue
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			if expr != nil {
+				return false
+			}
+			field, ok := node.(*ast.Field)
+			if !ok {
+				return true
+			}
+			for _, name := range field.Names {
+				if name.Pos() == obj.Pos() {
+					expr = field.Type
+					return false
+				}
+			}
+			return true
+		})
+	}
+	for {
+		switch e := expr.(type) {
+		case *ast.ArrayType:
+			expr = e.Elt
+			continue
+		case *ast.StarExpr:
+			expr = e.X
+			continue
+		case *ast.SelectorExpr:
+			expr = e.Sel
+			continue
+		case *ast.Ident:
+			typeName, ok := pkg.TypesInfo.Uses[e].(*types.TypeName)
+			if ok && typeName.IsAlias() {
+				return typeName.Name()
+			}
+		}
+		return ""
+	}
+}
+
+// aliasType is implemented by *types.Alias, which is only present
+// in type information when the gotypesalias GODEBUG setting is on.
+// Otherwise aliases are already resolved.
+type aliasType interface {
+	types.Type
+	Obj() *types.TypeName
+	Rhs() types.Type
+}
+
+// tagExample parses the value of an example struct tag for
+// a field of type ftype.
+// Numbers and bools are parsed, and slices are parsed as JSON,
+// so they match examples from comment metadata.
+func tagExample(ftype FieldType, value string) (interface{}, error) {
+	if ftype.Multiple || ftype.Map != nil {
+		var example interface{}
+		if err := json.Unmarshal([]byte(value), &example); err != nil {
+			return nil, err
+		}
+		return example, nil
+	}
+	switch ftype.JSType {
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "boolean":
+		return strconv.ParseBool(value)
+	}
+	return value, nil
+}
+
+// timeExample gets the example value for a time.Time field.
+func (p *Parser) timeExample(f Field) string {
+	if f.Metadata["format"] == "date" {
+		if p.DateExample != "" {
+			return p.DateExample
+		}
+		return "2021-01-02"
+	}
+	if p.TimeExample != "" {
+		return p.TimeExample
+	}
+	return "2021-01-02T15:04:05Z"
+}
+
+// idField gets the name of the field that identifies the object.
+// Fields with id: true metadata are preferred over fields called ID
+// or Id, and only one field may have it.
+func idField(fields []Field) (string, error) {
+	var explicit, inferred string
+	for _, field := range fields {
+		id, err := metadataBool(field.Metadata, "id", false)
+		if err != nil {
+			return "", errors.Wrap(err, field.Name)
+		}
+		if id {
+			if explicit != "" {
+				return "", errors.Errorf("id: %s and %s cannot both be the id field", explicit, field.Name)
+			}
+			explicit = field.Name
+		}
+		if inferred == "" && (field.Name == "ID" || field.Name == "Id") {
+			inferred = field.Name
+		}
+	}
+	if explicit != "" {
+		return explicit, nil
+	}
+	return inferred, nil
+}
+
+// numberFieldExample gets an example for number fields, which
+// depends on the type (see numberExample) and is clamped into the
+// range of any min and max metadata, so the docs do not show invalid
+// values.
+// Examples are also kept within the values the Go type can hold, so
+// unsigned types never get negative examples.
+// Returns false if the field is not a number.
+func numberFieldExample(f Field) (float64, bool) {
+	if f.Type.JSType != "number" || f.Type.IsEnum || f.Type.Multiple || f.Type.IsMap() {
+		return 0, false
+	}
+	example := numberExample(f.Type.TypeName)
+	if max, ok := f.Metadata["max"].(float64); ok && example > max {
+		example = max
+	}
+	if min, ok := f.Metadata["min"].(float64); ok && example < min {
+		example = min
+	}
+	if typeMin, typeMax, ok := intRange(f.Type.TypeName); ok {
+		example = math.Max(typeMin, math.Min(typeMax, math.Round(example)))
+	}
+	return example, true
+}
+
+// numberExample gets the example for a number of the Go type
+// typeName, which fits in the type and suits its size: 8 bit
+// integers get 34, 16 bit integers 1234, 64 bit integers
+// 1234567890123, other integers 334, and floats 1.235.
+func numberExample(typeName string) float64 {
+	switch typeName {
+	case "int8", "uint8":
+		return 34
+	case "int16", "uint16":
+		return 1234
+	case "int64", "uint64":
+		return 1234567890123
+	case "float32", "float64":
+		return 1.235
+	}
+	return 334
+}
+
+// checkRange checks that the min and max metadata of a field leave
+// some values that the field can hold. A min larger than the max,
+// or a range outside of the values of an integer type (like a max of
+// -1 on a uint) is an error.
+func checkRange(f Field) error {
+	min, hasMin := f.Metadata["min"].(float64)
+	max, hasMax := f.Metadata["max"].(float64)
+	if hasMin && hasMax && min > max {
+		return errors.Errorf("min %v is larger than max %v", min, max)
+	}
+	typeMin, typeMax, ok := intRange(f.Type.TypeName)
+	if !ok || f.Type.Multiple || f.Type.IsMap() {
+		return nil
+	}
+	if hasMin && min > typeMax {
+		return errors.Errorf("min %v is larger than any %s", min, f.Type.TypeName)
+	}
+	if hasMax && max < typeMin {
+		return errors.Errorf("max %v is smaller than any %s", max, f.Type.TypeName)
+	}
+	return nil
+}
+
+// intRange gets the smallest and largest values that an integer of
+// the Go type typeName can hold.
+// Returns false if typeName is not an integer type.
+func intRange(typeName string) (float64, float64, bool) {
+	switch typeName {
+	case "int8":
+		return math.MinInt8, math.MaxInt8, true
+	case "int16":
+		return math.MinInt16, math.MaxInt16, true
+	case "int32":
+		return math.MinInt32, math.MaxInt32, true
+	case "int", "int64":
+		return math.MinInt64, math.MaxInt64, true
+	case "uint8":
+		return 0, math.MaxUint8, true
+	case "uint16":
+		return 0, math.MaxUint16, true
+	case "uint32":
+		return 0, math.MaxUint32, true
+	case "uint", "uint64", "uintptr":
+		return 0, math.MaxUint64, true
+	}
+	return 0, 0, false
+}
+
+// isTimeType gets whether typ is time.Time.
+func isTimeType(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// isTimeFieldType gets whether the FieldType describes a time.Time.
+func isTimeFieldType(ftype FieldType) bool {
+	return ftype.Package == "time" && ftype.CleanObjectName == "Time"
+}
+
+// isErrorField gets whether field is the Error field added to
+// output objects by addOutputFields, which is the only OutputOnly
+// field.
+func isErrorField(field Field) bool {
+	return field.OutputOnly
+}
+
 // addOutputFields adds built-in fields to the response objects
 // mentioned in p.outputObjects.
 func (p *Parser) addOutputFields() error {
@@ -544,16 +2106,47 @@ func (p *Parser) addOutputFields() error {
 		OmitEmpty:      true,
 		Name:           "Error",
 		NameLowerCamel: "error",
+		NameUpperCamel: "Error",
 		Comment:        "Error is string explaining what went wrong. Empty if everything was fine.",
 		Type: FieldType{
-			TypeName:  "string",
-			JSType:    "string",
-			SwiftType: "String",
-			TSType:    "string",
-			DartType:  "String",
+			TypeName:   "string",
+			JSType:     "string",
+			SwiftType:  "String",
+			TSType:     "string",
+			DartType:   "String",
+			PythonType: "str",
+			RustType:   "String",
+			KotlinType: "String",
+			CSharpType: "string",
 		},
-		Metadata: map[string]interface{}{},
-		Example:  "something went wrong",
+		Metadata:   map[string]interface{}{},
+		Example:    "something went wrong",
+		OutputOnly: true,
+	}
+	if p.ErrorObject != "" {
+		errorObject, err := p.def.Object(p.ErrorObject)
+		if err != nil {
+			return errors.Wrapf(err, "ErrorObject %q", p.ErrorObject)
+		}
+		errorField.Comment = "Error describes what went wrong. Null if everything was fine."
+		errorField.Type = FieldType{
+			TypeID:               errorObject.TypeID,
+			TypeName:             "*" + errorObject.Name,
+			ObjectName:           "*" + errorObject.Name,
+			ExternalObjectName:   "*" + errorObject.ExternalObjectName,
+			CleanObjectName:      errorObject.Name,
+			ObjectNameLowerCamel: camelizeDown(errorObject.Name),
+			IsObject:             true,
+			JSType:               "object",
+			TSType:               errorObject.Name,
+			SwiftType:            errorObject.Name,
+			DartType:             errorObject.Name + "?",
+			PythonType:           "Optional[" + errorObject.Name + "]",
+			RustType:             "Option<" + errorObject.Name + ">",
+			KotlinType:           errorObject.Name + "?",
+			CSharpType:           camelizeUp(errorObject.Name),
+		}
+		errorField.Example = nil
 	}
 	for typeName := range p.outputObjects {
 		obj, err := p.def.Object(typeName)
@@ -561,6 +2154,12 @@ func (p *Parser) addOutputFields() error {
 			// skip if we can't find it - it must be excluded
 			continue
 		}
+		errorField.ObjectName = obj.Name
+		swiftOptional, err := swiftOptionalMode(obj.Metadata, errorField.Metadata)
+		if err != nil {
+			return errors.Wrap(err, obj.Name)
+		}
+		errorField.Type.SwiftTypeFull = swiftTypeFull(errorField.Type, swiftOptional)
 		obj.Fields = append(obj.Fields, errorField)
 	}
 	return nil
@@ -571,6 +2170,86 @@ func (p *Parser) wrapErr(err error, pkg *packages.Package, pos token.Pos) error
 	return errors.Wrap(err, position.String())
 }
 
+// isContextType gets whether typ is context.Context.
+func isContextType(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// metadataString gets the string value for key from metadata,
+// or defaultValue if it is missing.
+// Returns an error if the value is not a string.
+func metadataString(metadata map[string]interface{}, key, defaultValue string) (string, error) {
+	val, ok := metadata[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", errors.Errorf("%s: expected string, got %T", key, val)
+	}
+	return s, nil
+}
+
+func metadataBool(metadata map[string]interface{}, key string, defaultValue bool) (bool, error) {
+	val, ok := metadata[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	b, ok := val.(bool)
+	if !ok {
+		return false, errors.Errorf("%s: expected bool, got %T", key, val)
+	}
+	return b, nil
+}
+
+// MetadataInt gets the int value for key from metadata, or
+// defaultValue if it is missing.
+// Metadata numbers are parsed as float64, so MetadataInt returns an
+// error if the value is not a whole number.
+func MetadataInt(metadata map[string]interface{}, key string, defaultValue int) (int, error) {
+	val, ok := metadata[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	switch n := val.(type) {
+	case int:
+		return n, nil
+	case float64:
+		if n != math.Trunc(n) || math.Abs(n) > 1<<53 {
+			return 0, errors.Errorf("%s: expected int, got %v", key, n)
+		}
+		return int(n), nil
+	}
+	return 0, errors.Errorf("%s: expected int, got %T", key, val)
+}
+
+// metadataStrings gets the list of strings for key from metadata,
+// or nil if it is missing.
+// Returns an error if the value is not a list of strings.
+func metadataStrings(metadata map[string]interface{}, key string) ([]string, error) {
+	val, ok := metadata[key]
+	if !ok {
+		return nil, nil
+	}
+	items, ok := val.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("%s: expected list of strings, got %T", key, val)
+	}
+	strs := make([]string, len(items))
+	for i := range items {
+		s, ok := items[i].(string)
+		if !ok {
+			return nil, errors.Errorf("%s: expected list of strings, got %T item", key, items[i])
+		}
+		strs[i] = s
+	}
+	return strs, nil
+}
+
 func isInSlice(slice []string, s string) bool {
 	for i := range slice {
 		if slice[i] == s {
@@ -594,7 +2273,11 @@ func (p *Parser) commentForType(name string) string {
 	if typ == nil {
 		return ""
 	}
-	return cleanComment(typ.Doc)
+	var directives string
+	if spec, ok := typ.Decl.Specs[0].(*ast.TypeSpec); ok {
+		directives = otoDirectives(typ.Decl.Doc, spec.Doc)
+	}
+	return cleanComment(typ.Doc + "\n" + directives)
 }
 
 func (p *Parser) commentForMethod(service, method string) string {
@@ -623,7 +2306,7 @@ outer:
 	if m == nil {
 		return ""
 	}
-	return cleanComment(m.Doc.Text())
+	return cleanComment(m.Doc.Text() + "\n" + otoDirectives(m.Doc))
 }
 
 func (p *Parser) commentForField(typeName, field string) string {
@@ -652,13 +2335,69 @@ outer:
 	if f == nil {
 		return ""
 	}
-	return cleanComment(f.Doc.Text())
+	return cleanComment(f.Doc.Text() + "\n" + otoDirectives(f.Doc))
+}
+
+// otoDirectivePrefix begins directive comments, like //oto:required.
+const otoDirectivePrefix = "oto:"
+
+// otoDirectives gets the //oto: directive lines from the comment
+// groups, which CommentGroup.Text leaves out.
+func otoDirectives(groups ...*ast.CommentGroup) string {
+	var lines []string
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "//"+otoDirectivePrefix) {
+				lines = append(lines, strings.TrimPrefix(c.Text, "//"))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 func cleanComment(s string) string {
 	return strings.TrimSpace(s)
 }
 
+// swiftOptionalMode gets the swift_optional metadata for a field,
+// falling back to the metadata of its object.
+// implicit (the default) makes optional types like String?, while
+// explicit makes Optional<String>.
+func swiftOptionalMode(objectMetadata, fieldMetadata map[string]interface{}) (string, error) {
+	mode, err := metadataString(objectMetadata, "swift_optional", "implicit")
+	if err != nil {
+		return "", err
+	}
+	mode, err = metadataString(fieldMetadata, "swift_optional", mode)
+	if err != nil {
+		return "", err
+	}
+	switch mode {
+	case "implicit", "explicit":
+		return mode, nil
+	}
+	return "", errors.Errorf("swift_optional: must be implicit or explicit, got %q", mode)
+}
+
+// swiftTypeFull gets the optional Swift type for ftype, in the
+// swift_optional mode.
+func swiftTypeFull(ftype FieldType, mode string) string {
+	swiftType := ftype.SwiftType
+	if ftype.Multiple {
+		swiftType = "[" + swiftType + "]"
+	}
+	if mode == "explicit" {
+		return "Optional<" + swiftType + ">"
+	}
+	return swiftType + "?"
+}
+
+// errorInterface is the built-in error interface.
+var errorInterface = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
 // metadataCommentRegex is the regex to pull key value metadata
 // used since we can't simply trust lines that contain a colon
 var metadataCommentRegex = regexp.MustCompile(`^.*: .*`)
@@ -667,12 +2406,24 @@ var metadataCommentRegex = regexp.MustCompile(`^.*: .*`)
 // It returns a map of metadata, and the
 // remaining comment string.
 // Metadata fields should succeed the comment string.
+// Lines may also be oto: directives, like oto:required or
+// oto:example="x", and both forms may be mixed.
+// key: value lines whose value is not JSON are always left in the
+// comment, whether or not there are directives, so colons in prose
+// are left alone.
 func (p *Parser) extractCommentMetadata(comment string) (map[string]interface{}, string, error) {
 	var lines []string
 	var metadata = make(map[string]interface{})
 	s := bufio.NewScanner(strings.NewReader(comment))
 	for s.Scan() {
 		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, otoDirectivePrefix) {
+			key, val := parseOtoDirective(strings.TrimPrefix(line, otoDirectivePrefix))
+			if key != "" {
+				metadata[key] = val
+			}
+			continue
+		}
 		if metadataCommentRegex.MatchString(line) {
 			line = strings.TrimSpace(line)
 			if line == "" {
@@ -684,14 +2435,14 @@ func (p *Parser) extractCommentMetadata(comment string) (map[string]interface{},
 			key := splitLine[0]
 			value := strings.TrimSpace(splitLine[1])
 			var val interface{}
-			if err := json.Unmarshal([]byte(value), &val); err != nil {
-				if p.Verbose {
-					fmt.Printf("(skipping) failed to marshal JSON value (%s): %s\n", err, value)
-				}
+			err := json.Unmarshal([]byte(value), &val)
+			if err == nil {
+				metadata[key] = val
 				continue
 			}
-			metadata[key] = val
-			continue
+			if p.Verbose {
+				fmt.Printf("(keeping as prose) failed to unmarshal JSON value (%s): %s\n", err, value)
+			}
 		}
 		line = strings.TrimSpace(line)
 		if line == "" {
@@ -701,3 +2452,20 @@ func (p *Parser) extractCommentMetadata(comment string) (map[string]interface{},
 	}
 	return metadata, strings.Join(lines, "\n"), nil
 }
+
+// parseOtoDirective parses the key or key=value from an oto: directive.
+// A key on its own is true, and values are JSON, or treated as
+// strings if they are not valid JSON.
+func parseOtoDirective(directive string) (string, interface{}) {
+	splitDirective := strings.SplitN(directive, "=", 2)
+	key := strings.TrimSpace(splitDirective[0])
+	if len(splitDirective) == 1 {
+		return key, true
+	}
+	value := strings.TrimSpace(splitDirective[1])
+	var val interface{}
+	if err := json.Unmarshal([]byte(value), &val); err != nil {
+		return key, value
+	}
+	return key, val
+}
@@ -2,9 +2,11 @@ package parser
 
 import (
 	"bytes"
+	"fmt"
 	"go/doc"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -211,6 +213,1257 @@ You will love it.`)
 	// log.Println(string(b))
 }
 
+func TestParseExampleFunc(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	parser.ExampleFunc = func(field Field) (interface{}, bool) {
+		if field.Type.TypeName != "string" {
+			return nil, false
+		}
+		return "overridden", true
+	}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	welcomeRequest, err := def.Object("WelcomeRequest")
+	is.NoErr(err)
+	is.Equal(welcomeRequest.Fields[0].Name, "To")
+	is.Equal(welcomeRequest.Fields[0].Example, "overridden") // string field
+	is.Equal(welcomeRequest.Fields[1].Example, "John Smith") // *string is left alone
+	is.Equal(welcomeRequest.Fields[2].Example, float64(3))   // int is left alone
+}
+
+func TestParseNameCollisions(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/collisions"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	is.Equal(len(def.NameCollisions), 1)
+	is.Equal(len(def.NameCollisions["Page"]), 2)
+	is.Equal(def.NameCollisions["Page"][0], "github.com/pacedotdev/oto/parser/testdata/collisions.Page")
+	is.Equal(def.NameCollisions["Page"][1], "github.com/pacedotdev/oto/testdata/services.Page")
+}
+
+func TestParseIgnoreImports(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/ignoreimports"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	_, found := def.Imports["context"]
+	is.Equal(found, false) // context is ignored by default
+	is.Equal(def.Imports["github.com/pacedotdev/oto/testdata/services"], "services")
+
+	runRequest, err := def.Object("RunRequest")
+	is.NoErr(err)
+	is.Equal(runRequest.Fields[0].Type.TypeName, "context.Context")
+
+	is.Equal(len(def.Services[0].Methods), 2)
+	run := def.Services[0].Methods[0]
+	is.Equal(run.Name, "Run")
+	is.Equal(run.TakesContext, false)
+	schedule := def.Services[0].Methods[1]
+	is.Equal(schedule.Name, "Schedule")
+	is.Equal(schedule.TakesContext, true)
+	is.Equal(schedule.InputObject.CleanObjectName, "ScheduleRequest")
+	is.Equal(schedule.OutputObject.CleanObjectName, "ScheduleResponse")
+
+	parser = New(patterns...)
+	parser.Lite = true
+	def, err = parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.Services[0].Methods[1].TakesContext, true)
+	is.Equal(def.Services[0].Methods[1].InputObject.CleanObjectName, "ScheduleRequest")
+}
+
+func TestParseTimeExamples(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/times"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	createEventRequest, err := def.Object("CreateEventRequest")
+	is.NoErr(err)
+	is.Equal(createEventRequest.Fields[0].Type.TypeName, "time.Time")
+	is.Equal(createEventRequest.Fields[0].Type.IsObject, false)
+	is.Equal(createEventRequest.Fields[0].Type.TSType, "string")
+	example, ok := createEventRequest.Fields[0].Example.(string)
+	is.True(ok) // example should be a string
+	_, err = time.Parse(time.RFC3339, example)
+	is.NoErr(err) // example should be RFC3339
+	is.Equal(createEventRequest.Fields[1].Example, "2021-01-02")
+	is.Equal(createEventRequest.Fields[2].Example, "2022-03-04T05:06:07Z") // explicit example
+
+	parser = New(patterns...)
+	parser.TimeExample = "2000-01-01T00:00:00Z"
+	parser.DateExample = "2000-01-01"
+	def, err = parser.Parse()
+	is.NoErr(err)
+	createEventRequest, err = def.Object("CreateEventRequest")
+	is.NoErr(err)
+	is.Equal(createEventRequest.Fields[0].Example, "2000-01-01T00:00:00Z")
+	is.Equal(createEventRequest.Fields[1].Example, "2000-01-01")
+}
+
+func TestParseContentTypes(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/contenttypes"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	is.Equal(len(def.Services), 1)
+	is.Equal(def.Services[0].Methods[0].Name, "ExportReport")
+	is.Equal(def.Services[0].Methods[0].RequestContentType, "application/json") // default
+	is.Equal(def.Services[0].Methods[0].ResponseContentType, "text/csv")
+	is.Equal(def.Services[0].Methods[1].Name, "UploadReport")
+	is.Equal(def.Services[0].Methods[1].RequestContentType, "multipart/form-data")
+	is.Equal(def.Services[0].Methods[1].ResponseContentType, "application/json") // default
+}
+
+func TestParseFieldAliases(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/aliases"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	updateProfileRequest, err := def.Object("UpdateProfileRequest")
+	is.NoErr(err)
+	is.Equal(updateProfileRequest.Fields[0].NameLowerCamel, "displayName")
+	is.Equal(len(updateProfileRequest.Fields[0].Aliases), 2)
+	is.Equal(updateProfileRequest.Fields[0].Aliases[0], "name")
+	is.Equal(updateProfileRequest.Fields[0].Aliases[1], "fullName")
+	is.Equal(len(updateProfileRequest.Fields[1].Aliases), 0) // no aliases
+}
+
+func TestParseFieldExcludeIn(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/exclusions"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	getAccountResponse, err := def.Object("GetAccountResponse")
+	is.NoErr(err)
+	is.Equal(getAccountResponse.Fields[0].Name, "Name")
+	is.Equal(getAccountResponse.Fields[0].IsExcludedIn("typescript"), false)
+	is.Equal(getAccountResponse.Fields[1].Name, "ServerNotes")
+	is.Equal(getAccountResponse.Fields[1].ExcludeIn, []string{"typescript"})
+	is.Equal(getAccountResponse.Fields[1].IsExcludedIn("typescript"), true)
+	is.Equal(getAccountResponse.Fields[1].IsExcludedIn("go"), false)
+	is.Equal(getAccountResponse.Fields[2].Name, "Internal")
+	is.Equal(getAccountResponse.Fields[2].IsExcludedIn("typescript"), true) // exclude: true
+	is.Equal(getAccountResponse.Fields[2].IsExcludedIn("go"), true)         // exclude: true
+}
+
+func TestExcludeInTargets(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/exclusions"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	getAccountResponse, err := def.Object("GetAccountResponse")
+	is.NoErr(err)
+	serverNotes := &getAccountResponse.Fields[1]
+	is.Equal(serverNotes.Name, "ServerNotes")
+	for _, tc := range []struct {
+		target   string
+		field    string
+		generate func() (string, error)
+	}{
+		{"openapi", `"serverNotes"`, func() (string, error) {
+			b, err := def.OpenAPI(OpenAPIOptions{})
+			return string(b), err
+		}},
+		{"jsonschema", `"serverNotes"`, func() (string, error) {
+			b, err := def.JSONSchemaBundle()
+			return string(b), err
+		}},
+		{"proto", "server_notes", func() (string, error) {
+			return def.Proto("exclusions")
+		}},
+		{"go", "ServerNotes", func() (string, error) {
+			return def.GoClient("exclusions")
+		}},
+		{"markdown", "serverNotes", func() (string, error) {
+			return def.Markdown("/oto")
+		}},
+		{"typescript", "serverNotes", def.TypeScriptMockServer},
+	} {
+		serverNotes.ExcludeIn = []string{"graphql"}
+		s, err := tc.generate()
+		is.NoErr(err)
+		is.True(strings.Contains(s, tc.field)) // only excluded in graphql
+		is.True(!strings.Contains(s, "never exposed") && !strings.Contains(s, `"internal"`))
+		serverNotes.ExcludeIn = []string{tc.target}
+		s, err = tc.generate()
+		is.NoErr(err)
+		is.True(!strings.Contains(s, tc.field)) // excluded in the target
+	}
+}
+
+func TestParseFieldObjectName(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	is.True(len(def.Objects) > 0)
+	for _, object := range def.Objects {
+		for _, field := range object.Fields {
+			is.Equal(field.ObjectName, object.Name) // field should know its owner
+		}
+	}
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	is.Equal(greetResponse.Fields[0].ObjectName, "GreetResponse")
+}
+
+func TestParseObjectNameTransform(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ObjectNameTransform = BaseObjectName
+	def, err := parser.Parse()
+	is.NoErr(err)
+	greetRequest, err := def.Object("GreetRequest")
+	is.NoErr(err)
+	is.Equal(greetRequest.BaseName, "Greet")
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	is.Equal(greetResponse.BaseName, "Greet")
+	greeting, err := def.Object("Greeting")
+	is.NoErr(err)
+	is.Equal(greeting.BaseName, "Greeting")
+
+	parser = New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err = parser.Parse()
+	is.NoErr(err)
+	greetRequest, err = def.Object("GreetRequest")
+	is.NoErr(err)
+	is.Equal(greetRequest.BaseName, "GreetRequest") // no transform
+}
+
+func TestStripSuffixes(t *testing.T) {
+	is := is.New(t)
+	is.Equal(BaseObjectName("GreetRequest"), "Greet")
+	is.Equal(BaseObjectName("GreetResponse"), "Greet")
+	is.Equal(BaseObjectName("Greeting"), "Greeting")
+	is.Equal(BaseObjectName("Request"), "Request")
+	strip := StripSuffixes("Input", "Output")
+	is.Equal(strip("GreetInput"), "Greet")
+	is.Equal(strip("GreetRequest"), "GreetRequest")
+}
+
+func TestParseMethodNameTransform(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	parser.MethodNameTransform = StripPrefixes("Get")
+	def, err := parser.Parse()
+	is.NoErr(err)
+	greeterService := def.Services[0]
+	is.Equal(greeterService.Name, "GreeterService")
+	is.Equal(greeterService.Methods[0].Name, "GetGreetings")
+	is.Equal(greeterService.Methods[0].NameTransformed, "Greetings")
+	is.Equal(greeterService.Methods[1].Name, "Greet")
+	is.Equal(greeterService.Methods[1].NameTransformed, "Greet")
+
+	parser = New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err = parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.Services[0].Methods[0].NameTransformed, "GetGreetings") // no transform
+}
+
+func TestStripPrefixes(t *testing.T) {
+	is := is.New(t)
+	strip := StripPrefixes("Get", "List")
+	is.Equal(strip("GetGreetings"), "Greetings")
+	is.Equal(strip("ListGreetings"), "Greetings")
+	is.Equal(strip("Getaway"), "Getaway") // not a word
+	is.Equal(strip("Get"), "Get")
+	is.Equal(strip("Greet"), "Greet")
+}
+
+func TestParseUnknownKeys(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/unknownkeys"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	saveRequest, err := def.Object("SaveRequest")
+	is.NoErr(err)
+	is.Equal(saveRequest.UnknownKeys, "strict")
+	settings, err := def.Object("Settings")
+	is.NoErr(err)
+	is.Equal(settings.UnknownKeys, "passthrough")
+	saveResponse, err := def.Object("SaveResponse")
+	is.NoErr(err)
+	is.Equal(saveResponse.UnknownKeys, "strip") // default
+}
+
+func TestServicesByModule(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/modules"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	modules := def.ServicesByModule()
+	is.Equal(len(modules), 3)
+	is.Equal(len(modules["billing"]), 2)
+	is.Equal(modules["billing"][0].Name, "InvoiceService")
+	is.Equal(modules["billing"][0].Module, "billing")
+	is.Equal(modules["billing"][1].Name, "PaymentService")
+	is.Equal(len(modules["accounts"]), 1)
+	is.Equal(modules["accounts"][0].Name, "UserService")
+	is.Equal(len(modules[""]), 1) // no module
+	is.Equal(modules[""][0].Name, "StatusService")
+}
+
+func TestParseDefaultSort(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/sorting"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.Services[0].Methods[0].Name, "Get")
+	is.Equal(def.Services[0].Methods[0].DefaultSort, "")
+	is.Equal(def.Services[0].Methods[1].Name, "List")
+	is.Equal(def.Services[0].Methods[1].DefaultSort, "created_at desc")
+}
+
+func TestParseBinary(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/binary"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	download := def.Services[0].Methods[0]
+	is.Equal(download.Name, "Download")
+	is.Equal(download.BinaryRequest, false)
+	is.Equal(download.BinaryResponse, true)
+	is.Equal(download.RequestContentType, "application/json")
+	is.Equal(download.ResponseContentType, "application/octet-stream")
+	upload := def.Services[0].Methods[1]
+	is.Equal(upload.Name, "Upload")
+	is.Equal(upload.BinaryRequest, true)
+	is.Equal(upload.BinaryResponse, false)
+	is.Equal(upload.RequestContentType, "application/octet-stream")
+	is.Equal(upload.ResponseContentType, "application/json")
+}
+
+func TestParseExampleTags(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/exampletags"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	createRequest, err := def.Object("CreateRequest")
+	is.NoErr(err)
+	is.Equal(len(createRequest.Fields), 6)
+	is.Equal(createRequest.Fields[0].Example, "Widget")
+	is.Equal(createRequest.Fields[1].Example, float64(42))
+	is.Equal(createRequest.Fields[2].Example, 9.99)
+	is.Equal(createRequest.Fields[3].Example, true)
+	is.Equal(createRequest.Fields[4].Example, []interface{}{"new", "sale"})
+	is.Equal(createRequest.Fields[5].Example, "From the comment") // comment wins
+	createResponse, err := def.Object("CreateResponse")
+	is.NoErr(err)
+	is.Equal(createResponse.Fields[0].Example, nil)
+}
+
+func TestParseRangeExamples(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/rangeexamples"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	searchRequest, err := def.Object("SearchRequest")
+	is.NoErr(err)
+	is.Equal(len(searchRequest.Fields), 6)
+	is.Equal(searchRequest.Fields[0].Name, "Limit")
+	is.True(searchRequest.Fields[0].Example.(float64) <= 10)
+	is.Equal(searchRequest.Fields[1].Example, float64(334)) // in range
+	is.Equal(searchRequest.Fields[2].Example, float64(500)) // raised to min
+	is.Equal(searchRequest.Fields[3].Example, float64(2))   // float raised to min
+	is.Equal(searchRequest.Fields[4].Example, float64(5))   // explicit example wins
+	is.Equal(searchRequest.Fields[5].Example, float64(334)) // no range
+}
+
+func TestParseRangeExamplesIntegerSizes(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/rangeexamples"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	checkRequest, err := def.Object("CheckRequest")
+	is.NoErr(err)
+	is.Equal(len(checkRequest.Fields), 8)
+	is.Equal(checkRequest.Fields[0].Type.TypeName, "uint8")
+	is.Equal(checkRequest.Fields[0].Example, float64(34))
+	is.Equal(checkRequest.Fields[1].Type.TypeName, "uint")
+	is.Equal(checkRequest.Fields[1].Example, float64(0)) // never negative
+	is.Equal(checkRequest.Fields[2].Type.TypeName, "int16")
+	is.Equal(checkRequest.Fields[2].Example, float64(1234))
+	is.Equal(checkRequest.Fields[3].Example, float64(30000)) // raised to min
+	is.Equal(checkRequest.Fields[4].Type.TypeName, "int64")
+	is.Equal(checkRequest.Fields[4].Example, float64(1000000)) // lowered to max
+	// without a range, the example depends on the type
+	is.Equal(checkRequest.Fields[5].Type.TypeName, "uint8")
+	is.Equal(checkRequest.Fields[5].Example, float64(34))
+	is.Equal(checkRequest.Fields[6].Type.TypeName, "int16")
+	is.Equal(checkRequest.Fields[6].Example, float64(1234))
+	is.Equal(checkRequest.Fields[7].Type.TypeName, "int64")
+	is.Equal(checkRequest.Fields[7].Example, float64(1234567890123))
+
+	parser = New("./testdata/rangeexamples/impossible")
+	parser.Verbose = testing.Verbose()
+	_, err = parser.Parse()
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "CheckRequest.Negative: max -1 is smaller than any uint"))
+}
+
+func TestCheckRange(t *testing.T) {
+	is := is.New(t)
+	for _, tc := range []struct {
+		typeName string
+		metadata map[string]interface{}
+		err      string
+	}{
+		{"int", map[string]interface{}{}, ""},
+		{"uint", map[string]interface{}{"min": -10.0, "max": 0.0}, ""},
+		{"float64", map[string]interface{}{"min": 5.0, "max": 1.0}, "min 5 is larger than max 1"},
+		{"int16", map[string]interface{}{"min": 40000.0}, "min 40000 is larger than any int16"},
+		{"uint8", map[string]interface{}{"max": -1.0}, "max -1 is smaller than any uint8"},
+		{"float64", map[string]interface{}{"max": -1.0}, ""},
+	} {
+		err := checkRange(Field{Type: FieldType{TypeName: tc.typeName}, Metadata: tc.metadata})
+		if tc.err == "" {
+			is.NoErr(err)
+			continue
+		}
+		is.True(err != nil)
+		is.Equal(err.Error(), tc.err)
+	}
+}
+
+func TestParseIDFields(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/idfields"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	getRequest, err := def.Object("GetRequest")
+	is.NoErr(err)
+	is.Equal(getRequest.IDField, "UserID") // explicit wins
+	user, err := def.Object("User")
+	is.NoErr(err)
+	is.Equal(user.IDField, "ID") // inferred
+	getResponse, err := def.Object("GetResponse")
+	is.NoErr(err)
+	is.Equal(getResponse.IDField, "")
+}
+
+func TestParseNameUpperCamel(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/namecases"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.Services[0].Methods[0].NameUpperCamel, "GetHTMLPage")
+	page, err := def.Object("Page")
+	is.NoErr(err)
+	is.Equal(page.NameUpperCamel, "Page") // already PascalCase
+	is.Equal(page.Fields[0].NameUpperCamel, "Title")
+	is.Equal(page.Fields[1].NameUpperCamel, "URL")
+	getHTMLPageRequest, err := def.Object("GetHtmlPageRequest")
+	is.NoErr(err)
+	is.Equal(getHTMLPageRequest.NameUpperCamel, "GetHTMLPageRequest")
+	is.Equal(getHTMLPageRequest.Fields[0].NameUpperCamel, "PageID")
+	is.Equal(getHTMLPageRequest.Fields[1].NameUpperCamel, "UserID")
+	getHTMLPageResponse, err := def.Object("GetHtmlPageResponse")
+	is.NoErr(err)
+	is.Equal(getHTMLPageResponse.Fields[1].Name, "Error")
+	is.Equal(getHTMLPageResponse.Fields[1].NameUpperCamel, "Error")
+}
+
+func TestParseRecursiveObjects(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/recursive"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	var names []string
+	for _, object := range def.Objects {
+		names = append(names, object.Name)
+	}
+	is.Equal(names, []string{"Author", "Comment", "GetThreadRequest", "GetThreadResponse"})
+	comment, err := def.Object("Comment")
+	is.NoErr(err)
+	is.Equal(len(comment.Fields), 4)
+	is.Equal(comment.Fields[1].Name, "Replies")
+	is.Equal(comment.Fields[1].Type.CleanObjectName, "Comment")
+	is.True(comment.Fields[1].Type.Multiple)
+	is.True(comment.Fields[1].Type.ElementIsPointer)
+	is.Equal(comment.Fields[2].Type.ObjectName, "*Comment")
+	author, err := def.Object("Author")
+	is.NoErr(err)
+	is.Equal(author.Fields[1].Type.CleanObjectName, "Comment") // mutual recursion
+}
+
+func TestParsePythonTypes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/maps").Parse()
+	is.NoErr(err)
+	getStatsResponse, err := def.Object("GetStatsResponse")
+	is.NoErr(err)
+	types := make(map[string]string)
+	for _, field := range getStatsResponse.Fields {
+		types[field.Name] = field.Type.PythonType
+	}
+	is.Equal(types["Counts"], "Dict[str, int]")
+	is.Equal(types["Batches"], "List[Dict[str, int]]")
+	is.Equal(types["Groups"], "Dict[str, List[int]]")
+	is.Equal(types["Greetings"], "Dict[str, Greeting]")
+	is.Equal(types["Extra"], "Dict[str, Any]")
+	is.Equal(types["Error"], "str")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.KeyTypePython, "str")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.ElementTypePython, "List[int]")
+
+	def, err = New("./testdata/pointerslices").Parse()
+	is.NoErr(err)
+	getGreetingsRequest, err := def.Object("GetGreetingsRequest")
+	is.NoErr(err)
+	is.Equal(getGreetingsRequest.Fields[0].Type.PythonType, "List[Optional[str]]")
+	is.Equal(getGreetingsRequest.Fields[1].Type.PythonType, "List[str]")
+	getGreetingsResponse, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	is.Equal(getGreetingsResponse.Fields[0].Type.PythonType, "List[Optional[Greeting]]")
+	is.Equal(getGreetingsResponse.Fields[1].Type.PythonType, "Optional[Greeting]")
+
+	def, err = New("./testdata/exampletags").Parse()
+	is.NoErr(err)
+	createRequest, err := def.Object("CreateRequest")
+	is.NoErr(err)
+	is.Equal(createRequest.Fields[0].Type.PythonType, "str")
+	is.Equal(createRequest.Fields[1].Type.PythonType, "int")
+	is.Equal(createRequest.Fields[2].Type.PythonType, "float")
+	is.Equal(createRequest.Fields[3].Type.PythonType, "bool")
+	is.Equal(createRequest.Fields[4].Type.PythonType, "List[str]")
+}
+
+func TestParseRustTypes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/maps").Parse()
+	is.NoErr(err)
+	getStatsResponse, err := def.Object("GetStatsResponse")
+	is.NoErr(err)
+	types := make(map[string]string)
+	for _, field := range getStatsResponse.Fields {
+		types[field.Name] = field.Type.RustType
+	}
+	is.Equal(types["Counts"], "HashMap<String, i64>")
+	is.Equal(types["Batches"], "Vec<HashMap<String, i64>>")
+	is.Equal(types["Groups"], "HashMap<String, Vec<i64>>")
+	is.Equal(types["Greetings"], "HashMap<String, Greeting>")
+	is.Equal(types["Extra"], "HashMap<String, serde_json::Value>")
+	is.Equal(types["Error"], "String")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.KeyTypeRust, "String")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.ElementTypeRust, "Vec<i64>")
+
+	def, err = New("./testdata/pointerslices").Parse()
+	is.NoErr(err)
+	getGreetingsRequest, err := def.Object("GetGreetingsRequest")
+	is.NoErr(err)
+	is.Equal(getGreetingsRequest.Fields[0].Type.RustType, "Vec<Option<String>>")
+	getGreetingsResponse, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	is.Equal(getGreetingsResponse.Fields[0].Type.RustType, "Vec<Option<Greeting>>")
+	is.Equal(getGreetingsResponse.Fields[1].Type.RustType, "Option<Greeting>")
+
+	def, err = New("./testdata/rangeexamples").Parse()
+	is.NoErr(err)
+	checkRequest, err := def.Object("CheckRequest")
+	is.NoErr(err)
+	is.Equal(checkRequest.Fields[0].Type.RustType, "u8")
+	is.Equal(checkRequest.Fields[1].Type.RustType, "u64")
+	is.Equal(checkRequest.Fields[2].Type.RustType, "i16")
+	is.Equal(checkRequest.Fields[4].Type.RustType, "i64")
+	searchRequest, err := def.Object("SearchRequest")
+	is.NoErr(err)
+	is.Equal(searchRequest.Fields[3].Type.RustType, "f64")
+	checkResponse, err := def.Object("CheckResponse")
+	is.NoErr(err)
+	is.Equal(checkResponse.Fields[0].Type.RustType, "bool")
+}
+
+func TestParseKotlinTypes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/maps").Parse()
+	is.NoErr(err)
+	getStatsResponse, err := def.Object("GetStatsResponse")
+	is.NoErr(err)
+	types := make(map[string]string)
+	for _, field := range getStatsResponse.Fields {
+		types[field.Name] = field.Type.KotlinType
+	}
+	is.Equal(types["Counts"], "Map<String, Long>")
+	is.Equal(types["Batches"], "List<Map<String, Long>>")
+	is.Equal(types["Groups"], "Map<String, List<Long>>")
+	is.Equal(types["Greetings"], "Map<String, Greeting>")
+	is.Equal(types["Extra"], "Map<String, Any>")
+	is.Equal(types["Error"], "String")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.KeyTypeKotlin, "String")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.ElementTypeKotlin, "List<Long>")
+
+	def, err = New("./testdata/pointerslices").Parse()
+	is.NoErr(err)
+	getGreetingsRequest, err := def.Object("GetGreetingsRequest")
+	is.NoErr(err)
+	is.Equal(getGreetingsRequest.Fields[0].Type.KotlinType, "List<String?>")
+	is.Equal(getGreetingsRequest.Fields[1].Type.KotlinType, "List<String>")
+	getGreetingsResponse, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	is.Equal(getGreetingsResponse.Fields[0].Type.KotlinType, "List<Greeting?>")
+	is.Equal(getGreetingsResponse.Fields[1].Type.KotlinType, "Greeting?")
+
+	def, err = New("./testdata/rangeexamples").Parse()
+	is.NoErr(err)
+	checkRequest, err := def.Object("CheckRequest")
+	is.NoErr(err)
+	is.Equal(checkRequest.Fields[0].Type.KotlinType, "Int")  // uint8
+	is.Equal(checkRequest.Fields[2].Type.KotlinType, "Int")  // int16
+	is.Equal(checkRequest.Fields[4].Type.KotlinType, "Long") // int64
+	searchRequest, err := def.Object("SearchRequest")
+	is.NoErr(err)
+	is.Equal(searchRequest.Fields[3].Type.KotlinType, "Double")
+	checkResponse, err := def.Object("CheckResponse")
+	is.NoErr(err)
+	is.Equal(checkResponse.Fields[0].Type.KotlinType, "Boolean")
+}
+
+func TestParseDartTypes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/maps").Parse()
+	is.NoErr(err)
+	getStatsResponse, err := def.Object("GetStatsResponse")
+	is.NoErr(err)
+	types := make(map[string]string)
+	for _, field := range getStatsResponse.Fields {
+		types[field.Name] = field.Type.DartType
+	}
+	is.Equal(types["Batches"], "List<Map<String, int>>")
+	is.Equal(types["Greetings"], "Map<String, Greeting>")
+	is.Equal(types["Error"], "String")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.KeyTypeDart, "String")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.ElementTypeDart, "List<int>")
+
+	def, err = New("./testdata/pointerslices").Parse()
+	is.NoErr(err)
+	getGreetingsRequest, err := def.Object("GetGreetingsRequest")
+	is.NoErr(err)
+	is.Equal(getGreetingsRequest.Fields[0].Type.DartType, "List<String?>")
+	is.Equal(getGreetingsRequest.Fields[1].Type.DartType, "List<String>")
+	getGreetingsResponse, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	is.Equal(getGreetingsResponse.Fields[0].Type.DartType, "List<Greeting?>")
+	is.Equal(getGreetingsResponse.Fields[1].Type.DartType, "Greeting?")
+
+	def, err = New("./testdata/valuepointers").Parse()
+	is.NoErr(err)
+	updateRequest, err := def.Object("UpdateRequest")
+	is.NoErr(err)
+	is.Equal(updateRequest.Fields[0].Type.DartType, "int?")
+	is.Equal(updateRequest.Fields[1].Type.DartType, "bool?")
+	is.Equal(updateRequest.Fields[2].Type.DartType, "List<double?>")
+	is.Equal(updateRequest.Fields[3].Type.DartType, "String?")
+}
+
+func TestParseCSharpTypes(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/maps").Parse()
+	is.NoErr(err)
+	getStatsResponse, err := def.Object("GetStatsResponse")
+	is.NoErr(err)
+	types := make(map[string]string)
+	for _, field := range getStatsResponse.Fields {
+		types[field.Name] = field.Type.CSharpType
+	}
+	is.Equal(types["Counts"], "Dictionary<string, long>")
+	is.Equal(types["Batches"], "List<Dictionary<string, long>>")
+	is.Equal(types["Groups"], "Dictionary<string, List<long>>")
+	is.Equal(types["Greetings"], "Dictionary<string, Greeting>")
+	is.Equal(types["Extra"], "Dictionary<string, object>")
+	is.Equal(types["Error"], "string")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.KeyTypeCSharp, "string")
+	is.Equal(getStatsResponse.Fields[2].Type.Map.ElementTypeCSharp, "List<long>")
+
+	def, err = New("./testdata/valuepointers").Parse()
+	is.NoErr(err)
+	updateRequest, err := def.Object("UpdateRequest")
+	is.NoErr(err)
+	is.Equal(updateRequest.Fields[0].Type.CSharpType, "long?")
+	is.Equal(updateRequest.Fields[1].Type.CSharpType, "bool?")
+	is.Equal(updateRequest.Fields[2].Type.CSharpType, "List<double?>")
+	is.Equal(updateRequest.Fields[3].Type.CSharpType, "string") // reference types are nullable
+	is.Equal(updateRequest.Fields[4].Type.CSharpType, "Owner")
+	updateResponse, err := def.Object("UpdateResponse")
+	is.NoErr(err)
+	is.Equal(updateResponse.Fields[0].Type.CSharpType, "long")
+
+	def, err = New("./testdata/rangeexamples").Parse()
+	is.NoErr(err)
+	checkRequest, err := def.Object("CheckRequest")
+	is.NoErr(err)
+	is.Equal(checkRequest.Fields[0].Type.CSharpType, "int")  // uint8
+	is.Equal(checkRequest.Fields[2].Type.CSharpType, "int")  // int16
+	is.Equal(checkRequest.Fields[4].Type.CSharpType, "long") // int64
+}
+
+func TestDefinitionErrorCodes(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/errorcodes"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	methods := def.Services[0].Methods
+	is.Equal(methods[0].Name, "Count")
+	is.Equal(len(methods[0].ErrorCodes), 0)
+	is.Equal(methods[1].ErrorCodes, []string{"FORBIDDEN", "CONFLICT"})
+	is.Equal(def.ErrorCodes(), []string{"CONFLICT", "FORBIDDEN", "NOT_FOUND"})
+}
+
+func TestParseConstDefaults(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/constdefaults"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	createRequest, err := def.Object("CreateRequest")
+	is.NoErr(err)
+	is.Equal(createRequest.Fields[0].Name, "Title")
+	is.Equal(createRequest.Fields[0].Metadata["default"], "Untitled")
+	is.Equal(createRequest.Fields[1].Name, "Priority")
+	is.Equal(createRequest.Fields[1].Metadata["default"], float64(1))
+	is.Equal(createRequest.Fields[2].Name, "Status")
+	is.Equal(createRequest.Fields[2].Metadata["default"], "active") // imported
+	is.Equal(createRequest.Fields[3].Name, "Notes")
+	is.Equal(createRequest.Fields[3].Metadata["default"], "None") // JSON
+	is.Equal(createRequest.Fields[4].Name, "Owner")
+	_, ok := createRequest.Fields[4].Metadata["default"]
+	is.True(!ok) // unknown constant
+}
+
+func TestParseStreamingDirection(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/streamingdirections"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	methods := make(map[string]Method)
+	for _, method := range def.Services[0].Methods {
+		methods[method.Name] = method
+	}
+	is.Equal(len(methods), 4)
+	is.Equal(methods["Get"].StreamingDirection, "none")
+	is.Equal(methods["Upload"].StreamingDirection, "client")
+	is.Equal(methods["Upload"].InputObject.CleanObjectName, "Message")
+	is.Equal(methods["Upload"].Streaming, false)
+	is.Equal(methods["Watch"].StreamingDirection, "server")
+	is.Equal(methods["Watch"].Streaming, true)
+	is.Equal(methods["Chat"].StreamingDirection, "bidi")
+	is.Equal(methods["Chat"].InputObject.CleanObjectName, "Message")
+	is.Equal(methods["Chat"].OutputObject.CleanObjectName, "Message")
+}
+
+func TestParseInputOutputObject(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/inputoutput"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	settings, err := def.Object("Settings")
+	is.NoErr(err)
+	is.Equal(len(settings.Fields), 2)
+	is.Equal(settings.Fields[0].Name, "Theme")
+	is.Equal(settings.Fields[0].OutputOnly, false)
+	is.Equal(settings.Fields[1].Name, "Error")
+	is.Equal(settings.Fields[1].OutputOnly, true)
+	inputFields := settings.InputFields()
+	is.Equal(len(inputFields), 1) // no error field as input
+	is.Equal(inputFields[0].Name, "Theme")
+	resetRequest, err := def.Object("ResetRequest")
+	is.NoErr(err)
+	is.Equal(len(resetRequest.Fields), 0) // input only
+}
+
+func TestParseCacheTTL(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/cachettl"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.Services[0].Methods[0].Name, "Get")
+	is.Equal(def.Services[0].Methods[0].CacheTTL, 60*time.Second)
+	is.Equal(def.Services[0].Methods[1].Name, "Update")
+	is.Equal(def.Services[0].Methods[1].CacheTTL, time.Duration(0))
+
+	parser = New("./testdata/cachettl/malformed")
+	parser.Verbose = testing.Verbose()
+	_, err = parser.Parse()
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), `cache_ttl: time: invalid duration "sixty seconds"`))
+}
+
+func TestDefinitionUsesMapsAndEnums(t *testing.T) {
+	is := is.New(t)
+	parser := New("./testdata/maps")
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.UsesMaps(), true)
+	is.Equal(def.UsesEnums(), false)
+
+	parser = New("./testdata/enums")
+	parser.Verbose = testing.Verbose()
+	def, err = parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.UsesEnums(), true)
+
+	parser = New("./testdata/services/pleasantries")
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err = parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.UsesMaps(), false)
+	is.Equal(def.UsesEnums(), false)
+}
+
+func TestParseErrorTypes(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/errortypes"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	methods := make(map[string]Method)
+	for _, method := range def.Services[0].Methods {
+		methods[method.Name] = method
+	}
+	is.Equal(methods["Greet"].ErrorType, "*ValidationError")
+	is.Equal(methods["Greet"].ReturnsError, true)
+	is.Equal(methods["Greet"].OutputObject.CleanObjectName, "GreetResponse")
+	is.Equal(methods["Farewell"].ErrorType, "error")
+	is.Equal(methods["Farewell"].ReturnsError, true)
+	is.Equal(methods["Farewell"].OutputObject.CleanObjectName, "FarewellResponse")
+	is.Equal(methods["Wave"].ErrorType, "")
+	is.Equal(methods["Wave"].ReturnsError, false)
+
+	parser = New(patterns...)
+	parser.Lite = true
+	def, err = parser.Parse()
+	is.NoErr(err)
+	is.Equal(len(def.Services), 1)
+	is.Equal(len(def.Services[0].Methods), 3)
+	liteMethods := make(map[string]Method)
+	for _, method := range def.Services[0].Methods {
+		liteMethods[method.Name] = method
+	}
+	is.Equal(liteMethods["Greet"].ErrorType, "*ValidationError")
+	is.Equal(liteMethods["Greet"].ReturnsError, true)
+	is.Equal(liteMethods["Greet"].OutputObject.CleanObjectName, "GreetResponse")
+	is.Equal(liteMethods["Farewell"].ErrorType, "error")
+	is.Equal(liteMethods["Farewell"].ReturnsError, true)
+	is.Equal(liteMethods["Wave"].ErrorType, "")
+	is.Equal(liteMethods["Wave"].ReturnsError, false)
+}
+
+func TestParseSwiftOptional(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/swiftoptional"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	updateRequest, err := def.Object("UpdateRequest")
+	is.NoErr(err)
+	is.Equal(updateRequest.Fields[0].Type.SwiftTypeFull, "String?")
+	is.Equal(updateRequest.Fields[1].Type.SwiftTypeFull, "Optional<[String]>")
+	is.Equal(updateRequest.Fields[2].Type.SwiftTypeFull, "Profile?")
+	updateResponse, err := def.Object("UpdateResponse")
+	is.NoErr(err)
+	is.Equal(updateResponse.Fields[0].Type.SwiftTypeFull, "Optional<Profile>")
+	is.Equal(updateResponse.Fields[1].Type.SwiftTypeFull, "Int?")
+	is.Equal(updateResponse.Fields[2].Name, "Error")
+	is.Equal(updateResponse.Fields[2].Type.SwiftTypeFull, "Optional<String>")
+}
+
+func TestParsePointerSlices(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/pointerslices"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	getGreetingsResponse, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	greetings := getGreetingsResponse.Fields[0].Type
+	is.Equal(greetings.Multiple, true)
+	is.Equal(greetings.ElementIsPointer, true)
+	is.Equal(greetings.IsObject, true)
+	is.Equal(greetings.CleanObjectName, "Greeting")
+	featured := getGreetingsResponse.Fields[1].Type
+	is.Equal(featured.Multiple, false)
+	is.Equal(featured.ElementIsPointer, false)
+	getGreetingsRequest, err := def.Object("GetGreetingsRequest")
+	is.NoErr(err)
+	is.Equal(getGreetingsRequest.Fields[0].Type.ElementIsPointer, true)  // []*string
+	is.Equal(getGreetingsRequest.Fields[1].Type.ElementIsPointer, false) // []string
+}
+
+func TestParseErrorObject(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/errorobject"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ErrorObject = "APIError"
+	def, err := parser.Parse()
+	is.NoErr(err)
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	is.Equal(len(greetResponse.Fields), 2)
+	errorField := greetResponse.Fields[1]
+	is.Equal(errorField.Name, "Error")
+	is.Equal(errorField.NameLowerCamel, "error")
+	is.Equal(errorField.OutputOnly, true)
+	is.Equal(errorField.OmitEmpty, true)
+	is.Equal(errorField.Type.IsObject, true)
+	is.Equal(errorField.Type.TypeName, "*APIError")
+	is.Equal(errorField.Type.CleanObjectName, "APIError")
+	is.Equal(errorField.Type.TSType, "APIError")
+	_, err = def.Object(errorField.Type.CleanObjectName)
+	is.NoErr(err)
+	greetRequest, err := def.Object("GreetRequest")
+	is.NoErr(err)
+	is.Equal(len(greetRequest.Fields), 1) // input objects are unchanged
+
+	parser = New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ErrorObject = "MissingError"
+	_, err = parser.Parse()
+	is.True(err != nil)
+}
+
+func TestParseEmbeddedInterfaces(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/embeddedinterfaces"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	is.Equal(def.Services[0].Name, "AccountService")
+	methods := make(map[string]Method)
+	for _, method := range def.Services[0].Methods {
+		methods[method.Name] = method
+	}
+	is.Equal(len(methods), 2)
+	history := methods["History"]
+	is.Equal(history.Comment, "History gets the changes that have been made.")
+	is.Equal(history.Metadata["auth"], "admin") // inherited
+	is.Equal(history.Metadata["audited"], true) // not overridden by the service
+	is.Equal(methods["Close"].Metadata["auth"], "owner")
+	_, ok := methods["Close"].Metadata["audited"]
+	is.True(!ok) // only embedded methods inherit
+
+	is.Equal(def.Services[1].Name, "Auditable")
+	_, ok = def.Services[1].Methods[0].Metadata["auth"]
+	is.True(!ok)
+}
+
+func TestObjectIsEffectivelyEmpty(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/modules"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	sendInvoiceResponse, err := def.Object("SendInvoiceResponse")
+	is.NoErr(err)
+	is.Equal(len(sendInvoiceResponse.Fields), 1) // only the injected error
+	is.Equal(sendInvoiceResponse.IsEffectivelyEmpty(), true)
+	checkRequest, err := def.Object("CheckRequest")
+	is.NoErr(err)
+	is.Equal(len(checkRequest.Fields), 0)
+	is.Equal(checkRequest.IsEffectivelyEmpty(), true)
+	checkResponse, err := def.Object("CheckResponse")
+	is.NoErr(err)
+	is.Equal(checkResponse.IsEffectivelyEmpty(), false)
+	declared := Object{
+		Fields: []Field{
+			{Name: "Error", NameLowerCamel: "error", Type: FieldType{TypeName: "string"}},
+		},
+	}
+	is.Equal(declared.IsEffectivelyEmpty(), false) // not the injected error field
+}
+
+func TestParseEmbeddedStructs(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/embedded"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.RequireComments = true
+	def, err := parser.Parse()
+	is.NoErr(err)
+	getArticleResponse, err := def.Object("GetArticleResponse")
+	is.NoErr(err)
+	is.Equal(len(getArticleResponse.Fields), 5) // CreatedAt, UpdatedAt, UpdatedBy, Title, Error
+	createdAt := getArticleResponse.Fields[0]
+	is.Equal(createdAt.Name, "CreatedAt") // two levels deep
+	is.Equal(createdAt.Comment, "CreatedAt is when the record was created.")
+	is.Equal(createdAt.ObjectName, "GetArticleResponse")
+	is.Equal(createdAt.Optional, false) // value embed
+	updatedAt := getArticleResponse.Fields[1]
+	is.Equal(updatedAt.Name, "UpdatedAt")
+	is.Equal(updatedAt.Comment, "UpdatedAt is when the record was last changed.")
+	is.Equal(updatedAt.Type.TypeName, "string") // Created.UpdatedAt is shadowed
+	updatedBy := getArticleResponse.Fields[2]
+	is.Equal(updatedBy.Name, "UpdatedBy")
+	is.Equal(updatedBy.Comment, "UpdatedBy is the user who last changed the record.")
+	is.Equal(updatedBy.ObjectName, "GetArticleResponse")
+	is.Equal(updatedBy.Optional, true) // pointer embed
+	is.Equal(updatedBy.OmitEmpty, true)
+	title := getArticleResponse.Fields[3]
+	is.Equal(title.Name, "Title")
+	is.Equal(title.Type.TypeName, "string") // Audit.Title is shadowed
+	is.Equal(title.Optional, false)
+
+	parser = New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.FlattenEmbedded = false
+	def, err = parser.Parse()
+	is.NoErr(err)
+	getArticleResponse, err = def.Object("GetArticleResponse")
+	is.NoErr(err)
+	is.Equal(len(getArticleResponse.Fields), 4) // Timestamps, Audit, Title, Error
+	is.Equal(getArticleResponse.Fields[0].Name, "Timestamps")
+	is.Equal(getArticleResponse.Fields[0].Type.IsObject, true)
+	is.Equal(getArticleResponse.Fields[1].Name, "Audit")
+	is.Equal(getArticleResponse.Fields[1].Type.IsOptional(), true)
+	timestamps, err := def.Object("Timestamps")
+	is.NoErr(err)
+	is.Equal(timestamps.Fields[0].Name, "Created")
+	is.Equal(timestamps.Fields[1].Name, "UpdatedAt")
+}
+
+func TestParseEmbeddedStructConflicts(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/embeddedconflicts"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	getRecordRequest, err := def.Object("GetRecordRequest")
+	is.NoErr(err)
+	is.Equal(len(getRecordRequest.Fields), 3) // ID, Owner, Kind
+	id := getRecordRequest.Fields[0]
+	is.Equal(id.Name, "ID")
+	is.Equal(id.Type.TypeName, "string") // A.ID is shallower than B.C.ID
+	owner := getRecordRequest.Fields[1]
+	is.Equal(owner.Name, "Owner")  // Base.Name and Extra.Name are dropped
+	is.Equal(owner.Optional, true) // from *Base
+	kind := getRecordRequest.Fields[2]
+	is.Equal(kind.Name, "Kind")
+	is.Equal(kind.Type.TypeName, "string") // the tagged field wins
+}
+
+func TestParseStrictTags(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/stricttags"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err) // unknown options are allowed by default
+	updateRequest, err := def.Object("UpdateRequest")
+	is.NoErr(err)
+	is.Equal(updateRequest.Fields[2].ParsedTags["json"].Options, []string{"omitemtpy"})
+
+	parser = New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.StrictTags = true
+	_, err = parser.Parse()
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), `UpdateRequest.Nickname`))
+	is.True(strings.Contains(err.Error(), `json tag: unknown option "omitemtpy"`))
+}
+
+func TestCheckJSONTagOptions(t *testing.T) {
+	is := is.New(t)
+	is.NoErr(checkJSONTagOptions(nil))
+	is.NoErr(checkJSONTagOptions([]string{"omitempty", "string"}))
+	is.True(checkJSONTagOptions([]string{"omitempty", "omitempty"}) != nil) // duplicate
+	is.True(checkJSONTagOptions([]string{"inline"}) != nil)                 // unknown
+}
+
+func TestParseSharedObjects(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/shared"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"InternalService"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+	queryRequest, err := def.Object("QueryRequest")
+	is.NoErr(err) // used by an excluded service, but also by others
+	is.Equal(queryRequest.UsedByServices, []string{"SearchService", "SuggestService"})
+	is.True(def.ObjectIsInput("QueryRequest"))
+	searchResponse, err := def.Object("SearchResponse")
+	is.NoErr(err)
+	is.Equal(searchResponse.UsedByServices, []string{"SearchService"})
+	_, err = def.Object("ReindexResponse")
+	is.Equal(err, ErrNotFound) // only used by the excluded service
+}
+
+func TestParseTypeAliases(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/typealiases"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	_, err = def.Object("Hello")
+	is.Equal(err, ErrNotFound) // aliases aren't objects
+	_, err = def.Object("Greeting")
+	is.NoErr(err)
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	is.Equal(greetResponse.Fields[0].Type.TypeName, "Greeting")
+	is.Equal(greetResponse.Fields[0].Type.IsObject, true)
+	is.Equal(greetResponse.Fields[0].Type.AliasName, "Hello")
+	is.Equal(greetResponse.Fields[1].Type.TypeName, "*Greeting")
+	is.Equal(greetResponse.Fields[1].Type.CleanObjectName, "Greeting")
+	is.Equal(greetResponse.Fields[1].Type.Multiple, true)
+	is.Equal(greetResponse.Fields[1].Type.AliasName, "Hello")
+}
+
+func TestParseRequireComments(t *testing.T) {
+	is := is.New(t)
+
+	parser := New("./testdata/comments/documented")
+	parser.Verbose = testing.Verbose()
+	parser.RequireComments = true
+	_, err := parser.Parse()
+	is.NoErr(err)
+
+	parser = New("./testdata/comments/undocumented")
+	parser.Verbose = testing.Verbose()
+	parser.RequireComments = true
+	_, err = parser.Parse()
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "GreetRequest.Name must have a comment"))
+	is.True(strings.Contains(err.Error(), "undocumented.go:11")) // position of the field
+
+	parser = New("./testdata/comments/undocumented")
+	parser.Verbose = testing.Verbose()
+	_, err = parser.Parse()
+	is.NoErr(err) // comments aren't required by default
+}
+
+func TestParseMaps(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/maps"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	getStatsResponse, err := def.Object("GetStatsResponse")
+	is.NoErr(err)
+
+	counts := getStatsResponse.Fields[0].Type
+	is.Equal(counts.TypeName, "map[string]int")
+	is.Equal(counts.IsMap(), true)
+	is.Equal(counts.Multiple, false)
+	is.Equal(counts.Map.KeyType, "string")
+	is.Equal(counts.Map.ElementType, "int")
+	is.Equal(counts.Map.ElementIsMultiple, false)
+	is.Equal(counts.Map.ElementTypeTS, "number")
+	is.Equal(counts.TSType, "Record<string, number>")
+	is.Equal(counts.SwiftType, "[String: Int]")
+	is.Equal(counts.DartType, "Map<String, int>")
+
+	batches := getStatsResponse.Fields[1].Type // []map[string]int
+	is.Equal(batches.Multiple, true)
+	is.Equal(batches.IsMap(), true)
+	is.Equal(batches.Map.KeyType, "string")
+	is.Equal(batches.Map.ElementType, "int")
+	is.Equal(batches.Map.ElementIsMultiple, false)
+	is.Equal(batches.TSType, "Record<string, number>")
+
+	groups := getStatsResponse.Fields[2].Type // map[string][]int
+	is.Equal(groups.Multiple, false)
+	is.Equal(groups.IsMap(), true)
+	is.Equal(groups.Map.ElementType, "int")
+	is.Equal(groups.Map.ElementIsMultiple, true)
+	is.Equal(groups.Map.ElementTypeTS, "number[]")
+	is.Equal(groups.TSType, "Record<string, number[]>")
+	is.Equal(groups.SwiftType, "[String: [Int]]")
+	is.Equal(groups.DartType, "Map<String, List<int>>")
+
+	greetings := getStatsResponse.Fields[3].Type // map[string]*Greeting
+	is.Equal(greetings.Map.ElementType, "*Greeting")
+	is.Equal(greetings.Map.CleanElementType, "Greeting")
+	is.Equal(greetings.Map.ElementIsObject, true)
+	is.Equal(greetings.TSType, "Record<string, Greeting>")
+	_, err = def.Object("Greeting")
+	is.NoErr(err) // map element objects are parsed
+
+	extra := getStatsResponse.Fields[4].Type // map[string]interface{}
+	is.Equal(extra.IsMap(), true)
+	is.Equal(extra.TSType, "object") // unchanged
+	is.Equal(extra.DartType, "Map<String, dynamic>")
+
+	getStatsRequest, err := def.Object("GetStatsRequest")
+	is.NoErr(err)
+	is.Equal(getStatsRequest.Fields[0].Type.TSType, "Record<string, string>")
+}
+
 func TestFieldTypeIsOptional(t *testing.T) {
 	is := is.New(t)
 
@@ -220,6 +1473,20 @@ func TestFieldTypeIsOptional(t *testing.T) {
 	is.Equal(f.IsOptional(), false)
 }
 
+func TestFieldTypeString(t *testing.T) {
+	is := is.New(t)
+
+	f := FieldType{TypeName: "string"}
+	is.Equal(f.String(), "string")
+	f = FieldType{TypeName: "*Greeting", Multiple: true}
+	is.Equal(f.String(), "[]*Greeting")
+	f = FieldType{TypeName: "map[string]int"}
+	is.Equal(f.String(), "map[string]int")
+	f = FieldType{TypeName: "services.Page"}
+	is.Equal(f.String(), "services.Page")
+	is.Equal(fmt.Sprintf("%v", f), "services.Page")
+}
+
 func TestExtractCommentMetadata(t *testing.T) {
 	is := is.New(t)
 
@@ -233,12 +1500,98 @@ func TestExtractCommentMetadata(t *testing.T) {
 		Kind is one of: monthly, weekly, tags-monthly, tags-weekly
 	`)
 	is.NoErr(err)
-	is.Equal(comment, "This is a comment")
+	is.Equal(comment, "This is a comment\nKind is one of: monthly, weekly, tags-monthly, tags-weekly") // not JSON, so prose
+	is.Equal(len(metadata), 3)
 	is.Equal(metadata["example"], "With an example")
 	is.Equal(metadata["required"], true)
 	is.Equal(metadata["monkey"], float64(24))
 }
 
+func TestMetadataInt(t *testing.T) {
+	is := is.New(t)
+
+	p := &Parser{}
+	p.Verbose = testing.Verbose()
+	metadata, _, err := p.extractCommentMetadata(`
+		Order is the position of the item.
+		order: 3
+		ratio: 1.5
+		label: "three"
+	`)
+	is.NoErr(err)
+	order, err := MetadataInt(metadata, "order", 0)
+	is.NoErr(err)
+	is.Equal(order, 3)
+	missing, err := MetadataInt(metadata, "missing", 7)
+	is.NoErr(err)
+	is.Equal(missing, 7) // default
+	_, err = MetadataInt(metadata, "ratio", 0)
+	is.True(err != nil) // fractional
+	_, err = MetadataInt(metadata, "label", 0)
+	is.True(err != nil) // not a number
+}
+
+func TestExtractCommentMetadataDirectives(t *testing.T) {
+	is := is.New(t)
+
+	p := &Parser{}
+	p.Verbose = testing.Verbose()
+	metadata, comment, err := p.extractCommentMetadata(`
+		Kind is one of: monthly, weekly.
+		oto:required
+		oto:example="x"
+		oto:max=10
+		oto:format=date
+	`)
+	is.NoErr(err)
+	is.Equal(comment, "Kind is one of: monthly, weekly.")
+	is.Equal(metadata["required"], true)
+	is.Equal(metadata["example"], "x")
+	is.Equal(metadata["max"], float64(10))
+	is.Equal(metadata["format"], "date") // not JSON, so a string
+	_, hasKind := metadata["Kind is one of"]
+	is.True(!hasKind) // prose is left alone with directives too
+
+	metadata, comment, err = p.extractCommentMetadata(`
+		Period is the time: start to end.
+		oto:required
+		max_length: 12
+		oto:example="weekly"
+		format: "period"
+	`)
+	is.NoErr(err)
+	is.Equal(comment, "Period is the time: start to end.")
+	is.Equal(len(metadata), 4)
+	is.Equal(metadata["required"], true)
+	is.Equal(metadata["example"], "weekly")
+	is.Equal(metadata["max_length"], float64(12)) // key: value alongside directives
+	is.Equal(metadata["format"], "period")
+}
+
+func TestParseDirectives(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/directives"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	is.Equal(len(def.Services), 1)
+	is.Equal(def.Services[0].Comment, "ReportService generates reports.")
+	is.Equal(def.Services[0].Metadata["featured"], true)
+	is.Equal(def.Services[0].Methods[0].Comment, "Generate makes a report.")
+	is.Equal(def.Services[0].Methods[0].Metadata["http_method"], "GET")
+	is.Equal(def.Services[0].Methods[0].Metadata["cache_control"], "max-age=60")
+
+	generateRequest, err := def.Object("GenerateRequest")
+	is.NoErr(err)
+	kind := generateRequest.Fields[0]
+	is.Equal(kind.Comment, "Kind is one of: monthly, weekly.")
+	is.Equal(kind.Metadata["required"], true)
+	is.Equal(kind.Metadata["max_length"], float64(7))
+	is.Equal(kind.Example, "monthly")
+}
+
 func TestObjectIsInputOutput(t *testing.T) {
 	is := is.New(t)
 	patterns := []string{"./testdata/services/pleasantries"}
@@ -300,3 +1653,50 @@ func TestMethodsByMetadata(t *testing.T) {
 	is.Equal(methodsByMetadata[1].Methods[1].Name, "two")
 
 }
+
+func TestMethodIsMutation(t *testing.T) {
+	is := is.New(t)
+
+	def := &Definition{
+		Services: []Service{
+			{
+				Methods: []Method{
+					{
+						Name:     "GetGreetings",
+						Metadata: map[string]interface{}{"http_method": "GET"},
+					},
+					{
+						Name:     "Greet",
+						Metadata: map[string]interface{}{"http_method": "POST"},
+					},
+					{
+						Name:     "CountGreetings",
+						Metadata: map[string]interface{}{"idempotent": true},
+					},
+					{
+						Name:     "RefreshGreetings",
+						Metadata: map[string]interface{}{"http_method": "GET", "mutation": true},
+					},
+					{
+						Name:     "SaveGreeting",
+						Metadata: map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+	methods := def.Services[0].Methods
+	is.Equal(methods[0].IsMutation(), false) // GET
+	is.Equal(methods[1].IsMutation(), true)  // POST
+	is.Equal(methods[2].IsMutation(), false) // idempotent
+	is.Equal(methods[3].IsMutation(), true)  // explicit mutation
+	is.Equal(methods[4].IsMutation(), true)  // default
+
+	queries := def.Queries()
+	is.Equal(len(queries), 2)
+	is.Equal(queries[0].Name, "GetGreetings")
+	is.Equal(queries[1].Name, "CountGreetings")
+	mutations := def.Mutations()
+	is.Equal(len(mutations), 3)
+	is.Equal(mutations[0].Name, "Greet")
+}
@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Proto generates a proto3 file for the services and objects.
+// Services become services with an rpc for each method, and objects
+// become messages with fields numbered in declaration order.
+// Slices are repeated fields, maps are map fields, and pointers to
+// scalars are optional. Times and enums are their JSON types.
+// Types that cannot be described, like interface{}, are an error.
+func (d *Definition) Proto(packageName string) (string, error) {
+	if packageName == "" {
+		packageName = d.PackageName
+	}
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by oto; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `syntax = "proto3";`)
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s;\n", packageName)
+	for _, service := range d.Services {
+		fmt.Fprintln(&buf)
+		writeProtoComment(&buf, "", service.Comment)
+		fmt.Fprintf(&buf, "service %s {\n", service.Name)
+		for _, method := range service.Methods {
+			writeProtoComment(&buf, "\t", method.Comment)
+			fmt.Fprintf(&buf, "\trpc %s(%s) returns (%s);\n", method.Name, method.InputObject.CleanObjectName, method.OutputObject.CleanObjectName)
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+	for _, object := range d.Objects {
+		fmt.Fprintln(&buf)
+		writeProtoComment(&buf, "", object.Comment)
+		fmt.Fprintf(&buf, "message %s {\n", object.Name)
+		for i, field := range object.Fields {
+			if field.IsExcludedIn("proto") {
+				// keep the numbers of the other fields stable
+				continue
+			}
+			typ, err := d.protoType(field.Type)
+			if err != nil {
+				return "", errors.Wrapf(err, "%s.%s", object.Name, field.Name)
+			}
+			name := snakeName(field.Name)
+			var options string
+			if protoJSONName(name) != field.NameLowerCamel {
+				options = " [json_name = " + strconv.Quote(field.NameLowerCamel) + "]"
+			}
+			writeProtoComment(&buf, "\t", field.Comment)
+			fmt.Fprintf(&buf, "\t%s %s = %d%s;\n", typ, name, i+1, options)
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+	return buf.String(), nil
+}
+
+// protoScalarTypes maps Go scalar types to proto scalar types.
+var protoScalarTypes = map[string]string{
+	"string":  "string",
+	"bool":    "bool",
+	"int":     "int64",
+	"int8":    "int32",
+	"int16":   "int32",
+	"int32":   "int32",
+	"int64":   "int64",
+	"uint":    "uint64",
+	"uint8":   "uint32",
+	"uint16":  "uint32",
+	"uint32":  "uint32",
+	"uint64":  "uint64",
+	"float32": "float",
+	"float64": "double",
+}
+
+// protoType gets the proto type for a field of type ftype, including
+// the repeated or optional label.
+func (d *Definition) protoType(ftype FieldType) (string, error) {
+	var typ string
+	switch {
+	case ftype.IsObject:
+		typ = ftype.CleanObjectName
+	case isTimeFieldType(ftype):
+		// times are strings on the wire
+		typ = "string"
+	case ftype.IsMap():
+		if ftype.Multiple {
+			return "", errors.Errorf("unsupported type %s: repeated maps are not supported", ftype)
+		}
+		var err error
+		typ, err = d.protoMapType(ftype)
+		if err != nil {
+			return "", err
+		}
+	case ftype.IsEnum:
+		enum, err := d.Enum(ftype.CleanObjectName)
+		if err != nil {
+			return "", errors.Wrapf(err, "Enum(%q)", ftype.CleanObjectName)
+		}
+		typ = protoScalarTypes[enum.BaseType]
+	case ftype.CleanObjectName == "":
+		// built-in fields, like Error, only have a TypeName
+		typ = protoScalarTypes[ftype.TypeName]
+	default:
+		typ = protoScalarTypes[ftype.CleanObjectName]
+	}
+	if typ == "" {
+		return "", errors.Errorf("unsupported type %s", ftype)
+	}
+	if ftype.Multiple {
+		return "repeated " + typ, nil
+	}
+	if ftype.IsOptional() && !ftype.IsObject {
+		return "optional " + typ, nil
+	}
+	return typ, nil
+}
+
+// protoMapType gets the proto map type for the map type ftype.
+// Keys must be strings, bools or integers, and values cannot be
+// slices.
+func (d *Definition) protoMapType(ftype FieldType) (string, error) {
+	key := protoScalarTypes[ftype.Map.CleanKeyType]
+	if key == "" || key == "float" || key == "double" {
+		return "", errors.Errorf("unsupported type %s: unsupported map key type", ftype)
+	}
+	if ftype.Map.ElementIsMultiple {
+		return "", errors.Errorf("unsupported type %s: maps of slices are not supported", ftype)
+	}
+	var element string
+	switch {
+	case ftype.Map.ElementIsObject:
+		element = ftype.Map.CleanElementType
+	case ftype.Map.ElementIsEnum:
+		element = protoScalarTypes[ftype.Map.elementEnumBaseType]
+	default:
+		element = protoScalarTypes[ftype.Map.CleanElementType]
+	}
+	if element == "" {
+		return "", errors.Errorf("unsupported type %s", ftype)
+	}
+	return "map<" + key + ", " + element + ">", nil
+}
+
+// protoJSONName gets the JSON name that proto3 uses for the field
+// called name, which is name in lower camel case, like userId for
+// user_id.
+func protoJSONName(name string) string {
+	var out strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// writeProtoComment writes comment as // lines with the indent.
+func writeProtoComment(buf *bytes.Buffer, indent, comment string) {
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintf(buf, "%s// %s\n", indent, line)
+	}
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestProto(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/proto"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	s, err := def.Proto("accounts.v1")
+	is.NoErr(err)
+	is.True(strings.HasPrefix(s, "// Code generated by oto; DO NOT EDIT.\n\nsyntax = \"proto3\";\n\npackage accounts.v1;\n"))
+	for _, should := range []string{
+		`// AccountService manages accounts.
+service AccountService {
+	// Create makes a new account.
+	// It returns the new account.
+	rpc Create(CreateRequest) returns (CreateResponse);
+	// Get gets an account.
+	rpc Get(GetRequest) returns (GetResponse);
+}
+`,
+		`// Account is a user account.
+message Account {
+	// UserID is the ID of the owner.
+	string user_id = 1 [json_name = "userID"];
+	string name = 2;
+	int64 age = 3;
+	double score = 4;
+	float ratio = 5;
+	int32 small = 6;
+	bool active = 7;
+	optional string nick = 8;
+	repeated string tags = 9;
+	string plan = 10;
+	string created = 11;
+	map<string, int64> limits = 12;
+}
+`,
+		`message CreateResponse {
+	Account account = 1;
+	repeated Account friends = 2;
+	map<string, Account> by_name = 3;
+	repeated uint32 versions = 4;
+	// Error is string explaining what went wrong. Empty if everything was fine.
+	string error = 5;
+}
+`,
+		`	string user_id = 1 [json_name = "user_id"];`,
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s\n\ngot: %s", should, s)
+		}
+	}
+
+	s, err = def.Proto("")
+	is.NoErr(err)
+	is.True(strings.Contains(s, "\npackage proto;\n")) // defaults to the package name
+}
+
+func TestProtoUnsupported(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/proto/unsupported").Parse()
+	is.NoErr(err)
+	_, err = def.Proto("")
+	is.True(err != nil)
+	is.Equal(err.Error(), "SaveRequest.Value: unsupported type interface{}")
+
+	def, err = New("./testdata/maps").Parse()
+	is.NoErr(err)
+	_, err = def.Proto("")
+	is.True(err != nil)
+	is.Equal(err.Error(), "GetStatsResponse.Batches: unsupported type []map[string]int: repeated maps are not supported")
+}
+
+func TestProtoJSONName(t *testing.T) {
+	is := is.New(t)
+	is.Equal(protoJSONName("user_id"), "userId")
+	is.Equal(protoJSONName("name"), "name")
+	is.Equal(protoJSONName("by_name"), "byName")
+}
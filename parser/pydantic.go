@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// PydanticModels generates Python Pydantic models for the enums and
+// objects.
+// Objects become BaseModel classes with a typed attribute for each
+// field, aliased to its JSON name, and enums become Enum classes.
+// Pointer and omitempty fields are Optional and default to None.
+func (d *Definition) PydanticModels() (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "# Code generated by oto; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "from __future__ import annotations")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "from enum import Enum")
+	fmt.Fprintln(&buf, "from typing import Any, Dict, List, Optional")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "from pydantic import BaseModel, Field")
+	for _, enum := range d.Enums {
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf)
+		base := "str"
+		if enum.BaseType != "string" {
+			base = "int"
+		}
+		var lines []string
+		for _, value := range enum.Values {
+			name := strings.ToUpper(pythonName(value.ShortName))
+			if s, ok := value.Value.(string); ok {
+				lines = append(lines, name+" = "+strconv.Quote(s))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s = %v", name, value.Value))
+		}
+		fmt.Fprintf(&buf, "class %s(%s, Enum):\n", enum.Name, base)
+		writePythonClassBody(&buf, enum.Comment, lines)
+	}
+	for _, object := range d.Objects {
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf)
+		var lines []string
+		for _, field := range object.Fields {
+			if field.IsExcludedIn("python") {
+				continue
+			}
+			typ, err := d.pydanticType(field.Type)
+			if err != nil {
+				return "", errors.Wrapf(err, "%s.%s", object.Name, field.Name)
+			}
+			args := []string{}
+			if field.Type.IsOptional() || field.OmitEmpty || field.Optional {
+				if !strings.HasPrefix(typ, "Optional[") {
+					typ = "Optional[" + typ + "]"
+				}
+				args = append(args, "default=None")
+			}
+			args = append(args, "alias="+strconv.Quote(field.NameLowerCamel))
+			if field.Comment != "" {
+				args = append(args, "description="+strconv.Quote(field.Comment))
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s = Field(%s)", pythonName(field.Name), typ, strings.Join(args, ", ")))
+		}
+		fmt.Fprintf(&buf, "class %s(BaseModel):\n", object.Name)
+		writePythonClassBody(&buf, object.Comment, lines)
+	}
+	return buf.String(), nil
+}
+
+// pydanticType gets the Python type hint for a field of type ftype.
+// Enums are referred to by name.
+func (d *Definition) pydanticType(ftype FieldType) (string, error) {
+	if !ftype.IsEnum {
+		return ftype.PythonType, nil
+	}
+	if _, err := d.Enum(ftype.CleanObjectName); err != nil {
+		return "", errors.Wrapf(err, "Enum(%q)", ftype.CleanObjectName)
+	}
+	typ := ftype.CleanObjectName
+	if ftype.Multiple {
+		if ftype.ElementIsPointer {
+			typ = "Optional[" + typ + "]"
+		}
+		return "List[" + typ + "]", nil
+	}
+	if ftype.IsOptional() {
+		return "Optional[" + typ + "]", nil
+	}
+	return typ, nil
+}
+
+// pythonKeywords are the Python keywords that cannot be used as
+// attribute names.
+var pythonKeywords = []string{
+	"and", "as", "assert", "async", "await", "break", "class",
+	"continue", "def", "del", "elif", "else", "except", "finally",
+	"for", "from", "global", "if", "import", "in", "is", "lambda",
+	"nonlocal", "not", "or", "pass", "raise", "return", "try",
+	"while", "with", "yield",
+}
+
+// pythonName converts a Go name into a snake case Python name, like
+// user_id for UserID. Keywords get an underscore suffix.
+func pythonName(name string) string {
+	name = snakeName(name)
+	if isInSlice(pythonKeywords, name) {
+		name += "_"
+	}
+	return name
+}
+
+// snakeName converts a Go name into snake case, like user_id for
+// UserID.
+func snakeName(name string) string {
+	var words []string
+	for _, word := range Split(name) {
+		if strings.IndexFunc(word, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) == -1 {
+			continue
+		}
+		words = append(words, strings.ToLower(word))
+	}
+	return strings.Join(words, "_")
+}
+
+// writePythonClassBody writes the indented body of a class, with
+// comment as its docstring followed by the lines.
+// Empty classes get a pass statement.
+func writePythonClassBody(buf *bytes.Buffer, comment string, lines []string) {
+	if comment != "" {
+		comment = strings.ReplaceAll(comment, `"""`, `\"\"\"`)
+		if strings.Contains(comment, "\n") {
+			comment = strings.ReplaceAll(comment, "\n", "\n    ") + "\n    "
+		}
+		fmt.Fprintf(buf, "    \"\"\"%s\"\"\"\n", comment)
+		if len(lines) > 0 {
+			fmt.Fprintln(buf)
+		}
+	} else if len(lines) == 0 {
+		lines = []string{"pass"}
+	}
+	for _, line := range lines {
+		fmt.Fprintf(buf, "    %s\n", line)
+	}
+}
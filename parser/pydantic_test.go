@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPydanticModels(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	s, err := def.PydanticModels()
+	is.NoErr(err)
+	is.True(strings.HasPrefix(s, "# Code generated by oto; DO NOT EDIT."))
+	is.True(strings.Contains(s, "from pydantic import BaseModel, Field\n"))
+	should := `class GreetResponse(BaseModel):
+    """GreetResponse is the response object containing a
+    person's greeting.
+    """
+
+    greeting: Optional[Greeting] = Field(default=None, alias="greeting", description="Greeting is the greeted person's Greeting.")
+    error: Optional[str] = Field(default=None, alias="error", description="Error is string explaining what went wrong. Empty if everything was fine.")
+`
+	if !strings.Contains(s, should) {
+		t.Errorf("missing: %s\n\ngot: %s", should, s)
+	}
+	is.True(strings.Contains(s, `    to: str = Field(alias="recipients", description="To is the address of the person to send the message to.")`))
+	is.True(strings.Contains(s, `    order_field: str = Field(alias="orderField")`))
+}
+
+func TestPydanticModelsEnums(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/enums").Parse()
+	is.NoErr(err)
+
+	s, err := def.PydanticModels()
+	is.NoErr(err)
+	should := `class Status(str, Enum):
+    """Status is the state of an item."""
+
+    ACTIVE = "active"
+    ARCHIVED = "archived"
+`
+	if !strings.Contains(s, should) {
+		t.Errorf("missing: %s\n\ngot: %s", should, s)
+	}
+	is.True(strings.Contains(s, "class Priority(int, Enum):"))
+	is.True(strings.Contains(s, "    LOW = 1\n"))
+	is.True(strings.Contains(s, `    status: Status = Field(alias="status", description="Status is the new status of the task.")`))
+}
+
+func TestPythonName(t *testing.T) {
+	is := is.New(t)
+	is.Equal(pythonName("UserID"), "user_id")
+	is.Equal(pythonName("PreviewHTML"), "preview_html")
+	is.Equal(pythonName("From"), "from_")
+}
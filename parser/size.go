@@ -0,0 +1,89 @@
+package parser
+
+// Rough sizes in bytes used by EstimatedJSONSize.
+const (
+	estimatedJSONStringSize  = 18 // short string, with quotes
+	estimatedJSONNumberSize  = 8
+	estimatedJSONBooleanSize = 5
+	estimatedJSONOtherSize   = 16
+	// estimatedJSONObjectSize is used for nested objects that
+	// cannot be resolved, or that have already been counted.
+	estimatedJSONObjectSize = 32
+	// estimatedJSONMultiple is the number of elements assumed for
+	// slices and maps.
+	estimatedJSONMultiple = 3
+)
+
+// EstimatedJSONSize gets a rough estimate of the number of bytes
+// this object takes up when encoded as JSON, which clients may use
+// to choose buffer sizes.
+// Nested objects are included (each only once per path), and
+// slices and maps are assumed to have a few elements.
+// It is a heuristic, and not suitable for anything that needs to
+// be accurate.
+func (o Object) EstimatedJSONSize() int {
+	if o.estimatedJSONSize > 0 {
+		return o.estimatedJSONSize
+	}
+	return estimateJSONSize(o, nil, map[string]bool{})
+}
+
+// addEstimatedJSONSizes sets the estimated JSON size of each object,
+// resolving nested objects with the definition.
+func (p *Parser) addEstimatedJSONSizes() {
+	for i := range p.def.Objects {
+		p.def.Objects[i].estimatedJSONSize = estimateJSONSize(p.def.Objects[i], &p.def, map[string]bool{})
+	}
+}
+
+// estimateJSONSize estimates the JSON size of o.
+// If def is nil, nested objects are counted as estimatedJSONObjectSize.
+func estimateJSONSize(o Object, def *Definition, seen map[string]bool) int {
+	seen[o.Name] = true
+	defer delete(seen, o.Name)
+	size := 2 // {}
+	for _, field := range o.Fields {
+		// "key":value,
+		size += len(field.NameLowerCamel) + 4
+		size += estimateJSONTypeSize(field.Type, def, seen)
+	}
+	return size
+}
+
+func estimateJSONTypeSize(ftype FieldType, def *Definition, seen map[string]bool) int {
+	if ftype.Multiple {
+		element := ftype
+		element.Multiple = false
+		return 2 + estimatedJSONMultiple*estimateJSONTypeSize(element, def, seen)
+	}
+	if ftype.Map != nil {
+		element := FieldType{
+			CleanObjectName: ftype.Map.CleanElementType,
+			Multiple:        ftype.Map.ElementIsMultiple,
+			IsObject:        ftype.Map.ElementIsObject,
+			JSType:          ftype.Map.ElementTypeJS,
+		}
+		// map keys are always strings in JSON
+		entrySize := estimatedJSONStringSize + 2 + estimateJSONTypeSize(element, def, seen)
+		return 2 + estimatedJSONMultiple*entrySize
+	}
+	if ftype.IsObject {
+		if def == nil || seen[ftype.CleanObjectName] {
+			return estimatedJSONObjectSize
+		}
+		object, err := def.Object(ftype.CleanObjectName)
+		if err != nil {
+			return estimatedJSONObjectSize
+		}
+		return estimateJSONSize(*object, def, seen)
+	}
+	switch ftype.JSType {
+	case "string":
+		return estimatedJSONStringSize
+	case "number":
+		return estimatedJSONNumberSize
+	case "boolean":
+		return estimatedJSONBooleanSize
+	}
+	return estimatedJSONOtherSize
+}
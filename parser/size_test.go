@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestObjectEstimatedJSONSize(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	greetResponse, err := def.Object("GreetResponse")
+	is.NoErr(err)
+	greeting, err := def.Object("Greeting")
+	is.NoErr(err)
+	size := greetResponse.EstimatedJSONSize()
+	is.True(size > greeting.EstimatedJSONSize()) // includes nested Greeting
+	is.True(size < 1024)                         // reasonable for a small object
+
+	getGreetingsResponse, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	is.True(getGreetingsResponse.EstimatedJSONSize() > greeting.EstimatedJSONSize()*estimatedJSONMultiple) // slices
+}
+
+func TestObjectEstimatedJSONSizeRecursive(t *testing.T) {
+	is := is.New(t)
+	def := Definition{
+		Objects: []Object{
+			{
+				Name: "Node",
+				Fields: []Field{
+					{NameLowerCamel: "name", Type: FieldType{JSType: "string"}},
+					{NameLowerCamel: "parent", Type: FieldType{CleanObjectName: "Node", IsObject: true}},
+				},
+			},
+		},
+	}
+	size := estimateJSONSize(def.Objects[0], &def, map[string]bool{})
+	is.Equal(size, 2+(4+4+estimatedJSONStringSize)+(6+4+estimatedJSONObjectSize)) // parent not descended into
+	is.True(def.Objects[0].EstimatedJSONSize() > 0)
+}
@@ -115,6 +115,23 @@ func camelizeDown(word string) string {
 	return strings.ToLower(word[:1]) + word[1:]
 }
 
+// camelizeUp converts a name into PascalCase, with acronyms in
+// upper case. "modelId" becomes "ModelID".
+func camelizeUp(word string) string {
+	if isAcronym(word) {
+		// entire word is an acronym
+		return strings.ToUpper(word)
+	}
+	words := Split(word)
+	for i := range words {
+		if isAcronym(words[i]) {
+			words[i] = strings.ToUpper(words[i])
+		}
+	}
+	word = strings.Join(words, "")
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
 func isAcronym(word string) bool {
 	for _, ac := range baseAcronyms {
 		if strings.EqualFold(ac, word) {
@@ -0,0 +1,89 @@
+package parser
+
+// DefinitionStats are counts of the things in a Definition.
+type DefinitionStats struct {
+	Services int `json:"services"`
+	Methods  int `json:"methods"`
+	Objects  int `json:"objects"`
+	// Fields is the total number of fields across all objects.
+	Fields int `json:"fields"`
+	Enums  int `json:"enums"`
+	// PaginatedMethods are methods whose input object has a
+	// conventional page field, like Cursor or Page.
+	PaginatedMethods int `json:"paginatedMethods"`
+	// Deprecated is the number of services, methods, objects and
+	// fields with deprecated metadata.
+	Deprecated int `json:"deprecated"`
+	// SLAMethods is the number of methods with sla_ms metadata.
+	SLAMethods int `json:"slaMethods"`
+	// MaxSLAMs is the largest SLAMs of all the methods.
+	MaxSLAMs int `json:"maxSLAMs"`
+}
+
+// statsPageFields are the names of the fields that make a method
+// paginated. They match the page fields of the pagination_fields
+// render helper.
+var statsPageFields = []string{"Cursor", "PageToken", "Page", "Offset"}
+
+// Stats counts the services, methods, objects, fields and enums in
+// the definition, along with how many methods are paginated or have
+// an SLA, and how many things are deprecated.
+func (d *Definition) Stats() DefinitionStats {
+	stats := DefinitionStats{
+		Services: len(d.Services),
+		Objects:  len(d.Objects),
+		Enums:    len(d.Enums),
+	}
+	pageObjects := make(map[string]bool)
+	for _, object := range d.Objects {
+		stats.Fields += len(object.Fields)
+		if isDeprecated(object.Metadata) {
+			stats.Deprecated++
+		}
+		for _, field := range object.Fields {
+			if isInSlice(statsPageFields, field.Name) {
+				pageObjects[object.Name] = true
+			}
+			if isDeprecated(field.Metadata) {
+				stats.Deprecated++
+			}
+		}
+	}
+	for _, service := range d.Services {
+		stats.Methods += len(service.Methods)
+		if isDeprecated(service.Metadata) {
+			stats.Deprecated++
+		}
+		for _, method := range service.Methods {
+			if pageObjects[method.InputObject.CleanObjectName] {
+				stats.PaginatedMethods++
+			}
+			if isDeprecated(method.Metadata) {
+				stats.Deprecated++
+			}
+			if method.SLAMs > 0 {
+				stats.SLAMethods++
+				if method.SLAMs > stats.MaxSLAMs {
+					stats.MaxSLAMs = method.SLAMs
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// isDeprecated gets whether the metadata marks something as
+// deprecated, with deprecated: true, a message explaining what to
+// use instead, or deprecated_by naming the replacement.
+func isDeprecated(metadata map[string]interface{}) bool {
+	if by, ok := metadata["deprecated_by"].(string); ok && by != "" {
+		return true
+	}
+	switch deprecated := metadata["deprecated"].(type) {
+	case bool:
+		return deprecated
+	case string:
+		return deprecated != ""
+	}
+	return false
+}
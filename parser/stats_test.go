@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDefinitionStats(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	stats := def.Stats()
+	is.Equal(stats.Services, 3)
+	is.Equal(stats.Methods, 4)
+	is.Equal(stats.Objects, 11)
+	is.Equal(stats.Fields, 21) // including the error fields
+	is.Equal(stats.Enums, 0)
+	is.Equal(stats.PaginatedMethods, 1) // GetGreetings
+	is.Equal(stats.Deprecated, 0)
+}
+
+func TestDefinitionStatsDeprecated(t *testing.T) {
+	is := is.New(t)
+	def := Definition{
+		Services: []Service{
+			{
+				Name:     "OldService",
+				Metadata: map[string]interface{}{"deprecated": true},
+				Methods: []Method{
+					{Name: "Old", Metadata: map[string]interface{}{"deprecated": "Use New instead."}},
+					{Name: "New", Metadata: map[string]interface{}{"deprecated": false}},
+				},
+			},
+		},
+		Objects: []Object{
+			{
+				Name: "OldRequest",
+				Fields: []Field{
+					{Name: "Limit", Metadata: map[string]interface{}{"deprecated": "Use PageSize instead."}},
+					{Name: "PageSize"},
+				},
+			},
+		},
+	}
+	is.Equal(def.Stats().Deprecated, 3)
+}
+
+func TestDefinitionStatsSLA(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/sla"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	methods := def.Services[0].Methods
+	is.Equal(methods[0].Name, "Reindex")
+	is.Equal(methods[0].SLAMs, 5000)
+	is.Equal(methods[1].Name, "Search")
+	is.Equal(methods[1].SLAMs, 200)
+	is.Equal(methods[2].Name, "Suggest")
+	is.Equal(methods[2].SLAMs, 0)
+
+	stats := def.Stats()
+	is.Equal(stats.SLAMethods, 2)
+	is.Equal(stats.MaxSLAMs, 5000)
+
+	parser = New("./testdata/sla/malformed")
+	_, err = parser.Parse()
+	is.True(err != nil)
+}
@@ -0,0 +1,22 @@
+package aliases
+
+// ProfileService manages profiles.
+type ProfileService interface {
+	// UpdateProfile updates a profile.
+	UpdateProfile(UpdateProfileRequest) UpdateProfileResponse
+}
+
+// UpdateProfileRequest is the request object for ProfileService.UpdateProfile.
+type UpdateProfileRequest struct {
+	// DisplayName is the name to display.
+	// aliases: ["name", "fullName"]
+	DisplayName string
+	// Bio is a short biography.
+	Bio string
+}
+
+// UpdateProfileResponse is the response object for ProfileService.UpdateProfile.
+type UpdateProfileResponse struct {
+	// DisplayName is the name to display.
+	DisplayName string
+}
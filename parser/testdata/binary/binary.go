@@ -0,0 +1,31 @@
+package binary
+
+// FileService stores files.
+type FileService interface {
+	// Download gets the contents of a file.
+	// binary_response: true
+	Download(DownloadRequest) FileResponse
+	// Upload stores a new file.
+	// binary_request: true
+	Upload(UploadRequest) UploadResponse
+}
+
+// DownloadRequest is the request object for FileService.Download.
+type DownloadRequest struct {
+	// FileID is the ID of the file to download.
+	// example: "file-123"
+	FileID string
+}
+
+// FileResponse is the response object for FileService.Download.
+type FileResponse struct{}
+
+// UploadRequest is the request object for FileService.Upload.
+type UploadRequest struct{}
+
+// UploadResponse is the response object for FileService.Upload.
+type UploadResponse struct {
+	// FileID is the ID of the new file.
+	// example: "file-123"
+	FileID string
+}
@@ -0,0 +1,33 @@
+package cachettl
+
+// ProductService provides products.
+type ProductService interface {
+	// Get gets a product.
+	// cache_ttl: "60s"
+	Get(GetRequest) GetResponse
+	// Update changes a product.
+	Update(UpdateRequest) UpdateResponse
+}
+
+// GetRequest is the request object for ProductService.Get.
+type GetRequest struct {
+	// ID is the ID of the product.
+	ID string
+}
+
+// GetResponse is the response object for ProductService.Get.
+type GetResponse struct {
+	// Name is the name of the product.
+	Name string
+}
+
+// UpdateRequest is the request object for ProductService.Update.
+type UpdateRequest struct {
+	// ID is the ID of the product.
+	ID string
+	// Name is the new name of the product.
+	Name string
+}
+
+// UpdateResponse is the response object for ProductService.Update.
+type UpdateResponse struct{}
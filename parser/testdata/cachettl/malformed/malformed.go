@@ -0,0 +1,14 @@
+package malformed
+
+// ProductService provides products.
+type ProductService interface {
+	// Get gets a product.
+	// cache_ttl: "sixty seconds"
+	Get(GetRequest) GetResponse
+}
+
+// GetRequest is the request object for ProductService.Get.
+type GetRequest struct{}
+
+// GetResponse is the response object for ProductService.Get.
+type GetResponse struct{}
@@ -0,0 +1,29 @@
+package collisions
+
+import (
+	"github.com/pacedotdev/oto/testdata/services"
+)
+
+// PageService lists pages.
+type PageService interface {
+	// ListPages gets a page of pages.
+	ListPages(ListPagesRequest) ListPagesResponse
+}
+
+// ListPagesRequest is the request object for PageService.ListPages.
+type ListPagesRequest struct {
+	// Page describes which page of data to get.
+	Page services.Page
+}
+
+// ListPagesResponse is the response object for PageService.ListPages.
+type ListPagesResponse struct {
+	// Pages are the pages.
+	Pages []Page
+}
+
+// Page is a page in a book, not a page of data.
+type Page struct {
+	// Number is the page number.
+	Number int
+}
@@ -0,0 +1,47 @@
+package constdefaults
+
+import "github.com/pacedotdev/oto/parser/testdata/enums/status"
+
+// Priority is how urgent a task is.
+type Priority int
+
+const (
+	// PriorityLow is for tasks that can wait.
+	PriorityLow Priority = iota
+	// PriorityHigh is for urgent tasks.
+	PriorityHigh
+)
+
+// DefaultTitle is the title given to tasks without one.
+const DefaultTitle = "Untitled"
+
+// TaskService manages tasks.
+type TaskService interface {
+	// Create makes a new task.
+	Create(CreateRequest) CreateResponse
+}
+
+// CreateRequest is the request object for TaskService.Create.
+type CreateRequest struct {
+	// Title is the title of the task.
+	// default: DefaultTitle
+	Title string
+	// Priority is how urgent the task is.
+	// default: PriorityHigh
+	Priority Priority
+	// Status is the initial status of the task.
+	// default: status.StatusActive
+	Status status.Status
+	// Notes are extra details about the task.
+	// default: "None"
+	Notes string
+	// Owner is who the task is for.
+	// default: NotAConstant
+	Owner string
+}
+
+// CreateResponse is the response object for TaskService.Create.
+type CreateResponse struct {
+	// ID is the ID of the new task.
+	ID string
+}
@@ -0,0 +1,39 @@
+package contenttypes
+
+// ReportService produces reports.
+type ReportService interface {
+	// ExportReport exports a report as CSV.
+	// responseContentType: "text/csv"
+	ExportReport(ExportReportRequest) ExportReportResponse
+	// UploadReport uploads a report.
+	// requestContentType: "multipart/form-data"
+	UploadReport(UploadReportRequest) UploadReportResponse
+}
+
+// ExportReportRequest is the request object for ReportService.ExportReport.
+type ExportReportRequest struct {
+	// ReportID is the ID of the report to export.
+	// example: "report-123"
+	ReportID string
+}
+
+// ExportReportResponse is the response object for ReportService.ExportReport.
+type ExportReportResponse struct {
+	// CSV is the exported report.
+	// example: "name,count"
+	CSV string
+}
+
+// UploadReportRequest is the request object for ReportService.UploadReport.
+type UploadReportRequest struct {
+	// Filename is the name of the uploaded file.
+	// example: "report.csv"
+	Filename string
+}
+
+// UploadReportResponse is the response object for ReportService.UploadReport.
+type UploadReportResponse struct {
+	// ReportID is the ID of the new report.
+	// example: "report-123"
+	ReportID string
+}
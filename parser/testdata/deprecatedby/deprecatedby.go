@@ -0,0 +1,21 @@
+package deprecatedby
+
+// ListService lists things.
+type ListService interface {
+	List(ListRequest) ListResponse
+}
+
+// ListRequest is the input for List.
+type ListRequest struct {
+	// Limit is the maximum number of items.
+	// deprecated_by: "pageSize"
+	Limit int
+	// PageSize is the number of items per page.
+	PageSize int
+}
+
+// ListResponse is the output for List.
+type ListResponse struct {
+	// Items are the items.
+	Items []string
+}
@@ -0,0 +1,27 @@
+package directives
+
+// ReportService generates reports.
+//
+//oto:featured
+type ReportService interface {
+	// Generate makes a report.
+	// cache_control: "max-age=60"
+	//oto:http_method="GET"
+	Generate(GenerateRequest) GenerateResponse
+}
+
+// GenerateRequest is the request object for ReportService.Generate.
+type GenerateRequest struct {
+	// Kind is one of: monthly, weekly.
+	// max_length: 7
+	//oto:required
+	//oto:example="monthly"
+	Kind string
+}
+
+// GenerateResponse is the response object for ReportService.Generate.
+type GenerateResponse struct {
+	// URL is where to download the report.
+	// example: "https://example.com/report.pdf"
+	URL string
+}
@@ -0,0 +1,44 @@
+package discriminators
+
+// MessageService sends messages.
+type MessageService interface {
+	// Send sends a message.
+	Send(SendRequest) SendResponse
+}
+
+// SendRequest is the request object for MessageService.Send.
+type SendRequest struct {
+	// Message is the message to send.
+	Message Message
+}
+
+// SendResponse is the response object for MessageService.Send.
+type SendResponse struct {
+	// ID is the ID of the sent message.
+	// example: "msg-123"
+	ID string
+}
+
+// Message is either a text or an image message, depending on Type.
+// discriminator: "type"
+type Message struct {
+	// Type is the kind of message.
+	// options: ["text", "image"]
+	// example: "text"
+	Type string `json:"type"`
+	// Sender is the name of whoever sent the message.
+	// example: "Mat"
+	Sender string `json:"sender"`
+	// Text is the body of a text message.
+	// variant: "text"
+	// example: "Hello"
+	Text string `json:"text"`
+	// ImageURL is the location of the image in an image message.
+	// variant: "image"
+	// example: "https://example.com/image.png"
+	ImageURL string `json:"imageURL"`
+	// Caption is shown alongside the message.
+	// variant: ["text", "image"]
+	// example: "Greetings"
+	Caption string `json:"caption"`
+}
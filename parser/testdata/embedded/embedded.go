@@ -0,0 +1,44 @@
+package embedded
+
+// ArticleService manages articles.
+type ArticleService interface {
+	// Get gets an article.
+	Get(GetArticleRequest) GetArticleResponse
+}
+
+// GetArticleRequest is the request object for ArticleService.Get.
+type GetArticleRequest struct {
+	// ArticleID is the ID of the article to get.
+	ArticleID string
+}
+
+// GetArticleResponse is the response object for ArticleService.Get.
+type GetArticleResponse struct {
+	Timestamps
+	*Audit
+	// Title is the title of the article.
+	Title string
+}
+
+// Timestamps are the times a record changed.
+type Timestamps struct {
+	Created
+	// UpdatedAt is when the record was last changed.
+	UpdatedAt string
+}
+
+// Created describes when a record was created.
+type Created struct {
+	// CreatedAt is when the record was created.
+	CreatedAt string
+	// UpdatedAt is shadowed by Timestamps.UpdatedAt.
+	UpdatedAt int
+}
+
+// Audit describes who changed a record, if known.
+type Audit struct {
+	// UpdatedBy is the user who last changed the record.
+	UpdatedBy string
+	// Title is shadowed by GetArticleResponse.Title.
+	Title int
+}
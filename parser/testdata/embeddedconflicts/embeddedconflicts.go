@@ -0,0 +1,66 @@
+package embeddedconflicts
+
+// RecordService gets records.
+type RecordService interface {
+	// Get gets a record.
+	Get(GetRecordRequest) GetRecordResponse
+}
+
+// GetRecordRequest is the request object for RecordService.Get.
+type GetRecordRequest struct {
+	A
+	B
+	*Base
+	*Extra
+	Kinds
+	Notes
+}
+
+// A has an ID one level deep.
+type A struct {
+	// ID is the ID from A.
+	ID string
+}
+
+// B embeds C, which has an ID two levels deep.
+type B struct {
+	C
+}
+
+// C has an ID.
+type C struct {
+	// ID is hidden by A.ID, which is less deeply embedded.
+	ID int
+}
+
+// Base is embedded as a pointer.
+type Base struct {
+	// Name clashes with Extra.Name at the same depth.
+	Name string
+	// Owner is the owner of the record.
+	Owner string
+}
+
+// Extra is embedded as a pointer.
+type Extra struct {
+	// Name clashes with Base.Name at the same depth.
+	Name string
+}
+
+// Kinds has a tagged field.
+type Kinds struct {
+	// Kind wins over Notes.Kind because it is tagged.
+	Kind string `json:"Kind"`
+}
+
+// Notes has an untagged field.
+type Notes struct {
+	// Kind is hidden by the tagged Kinds.Kind.
+	Kind int
+}
+
+// GetRecordResponse is the response object for RecordService.Get.
+type GetRecordResponse struct {
+	// Found is true if the record was found.
+	Found bool
+}
@@ -0,0 +1,33 @@
+package embeddedinterfaces
+
+// Auditable is implemented by services that keep a history.
+type Auditable interface {
+	// History gets the changes that have been made.
+	// audited: true
+	History(HistoryRequest) HistoryResponse
+}
+
+// AccountService manages accounts.
+// auth: "admin"
+// audited: false
+type AccountService interface {
+	Auditable
+	// Close closes the account.
+	// auth: "owner"
+	Close(CloseRequest) CloseResponse
+}
+
+// HistoryRequest is the request object for Auditable.History.
+type HistoryRequest struct{}
+
+// HistoryResponse is the response object for Auditable.History.
+type HistoryResponse struct {
+	// Changes describe each change.
+	Changes []string
+}
+
+// CloseRequest is the request object for AccountService.Close.
+type CloseRequest struct{}
+
+// CloseResponse is the response object for AccountService.Close.
+type CloseResponse struct{}
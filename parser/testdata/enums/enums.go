@@ -0,0 +1,57 @@
+package enums
+
+import "github.com/pacedotdev/oto/parser/testdata/enums/status"
+
+// TaskService manages tasks.
+type TaskService interface {
+	// Update updates a task.
+	Update(UpdateTaskRequest) UpdateTaskResponse
+}
+
+// Priority is how urgent a task is.
+type Priority int
+
+const (
+	PriorityLow  Priority = 1 // PriorityLow can wait.
+	PriorityHigh Priority = 2 // PriorityHigh is urgent.
+)
+
+// Color is the color of a task.
+type Color string
+
+const (
+	// ColorRed is for important tasks.
+	ColorRed Color = "red"
+	// Green is for tasks that are going well.
+	Green Color = "green"
+)
+
+// Size is how big a task is.
+type Size int
+
+const (
+	// SizeSmall takes an hour.
+	SizeSmall Size = iota
+	// SizeLarge takes a day.
+	SizeLarge
+)
+
+// Label is free text, not an enum.
+type Label string
+
+// UpdateTaskRequest is the request object for TaskService.Update.
+type UpdateTaskRequest struct {
+	// Status is the new status of the task.
+	Status status.Status
+	// Priority is the new priority of the task.
+	Priority Priority
+	// Label is the new label of the task.
+	Label Label
+	// Color is the new color of the task.
+	Color Color
+	// Estimates are the sizes of the task given by each person.
+	Estimates map[string][]Size
+}
+
+// UpdateTaskResponse is the response object for TaskService.Update.
+type UpdateTaskResponse struct{}
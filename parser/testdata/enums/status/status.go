@@ -0,0 +1,15 @@
+// Package status contains shared status types.
+package status
+
+// Status is the state of an item.
+type Status string
+
+const (
+	// StatusActive is for items in use.
+	StatusActive Status = "active"
+	// StatusArchived is for items that are kept but not in use.
+	StatusArchived Status = "archived"
+)
+
+// Unrelated is not part of the Status enum.
+const Unrelated = "unrelated"
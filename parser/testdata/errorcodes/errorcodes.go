@@ -0,0 +1,33 @@
+package errorcodes
+
+// DocumentService manages documents.
+type DocumentService interface {
+	// Get gets a document.
+	// error_codes: ["NOT_FOUND", "FORBIDDEN"]
+	Get(GetRequest) GetResponse
+	// Delete deletes a document.
+	// error_codes: ["FORBIDDEN", "CONFLICT"]
+	Delete(DeleteRequest) DeleteResponse
+	// Count counts the documents.
+	Count(CountRequest) CountResponse
+}
+
+type GetRequest struct {
+	ID string
+}
+
+type GetResponse struct {
+	Title string
+}
+
+type DeleteRequest struct {
+	ID string
+}
+
+type DeleteResponse struct{}
+
+type CountRequest struct{}
+
+type CountResponse struct {
+	Count int
+}
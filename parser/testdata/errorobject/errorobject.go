@@ -0,0 +1,31 @@
+package errorobject
+
+// GreeterService greets people.
+type GreeterService interface {
+	// Greet greets someone.
+	Greet(GreetRequest) GreetResponse
+}
+
+// GreetRequest is the request object for GreeterService.Greet.
+type GreetRequest struct {
+	// Name is the name of the person to greet.
+	Name string
+}
+
+// GreetResponse is the response object for GreeterService.Greet.
+type GreetResponse struct {
+	// Greeting is the greeting.
+	Greeting string
+}
+
+// APIError describes what went wrong.
+type APIError struct {
+	// Code is a machine readable error code.
+	// example: "not_found"
+	Code string
+	// Message is a human readable description of the error.
+	// example: "Person not found."
+	Message string
+	// Details are extra details about the error.
+	Details map[string]string
+}
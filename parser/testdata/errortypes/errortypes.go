@@ -0,0 +1,45 @@
+package errortypes
+
+// GreeterService greets people.
+type GreeterService interface {
+	// Greet greets someone.
+	Greet(GreetRequest) (GreetResponse, *ValidationError)
+	// Farewell says goodbye to someone.
+	Farewell(FarewellRequest) (FarewellResponse, error)
+	// Wave waves at someone.
+	Wave(WaveRequest) WaveResponse
+}
+
+// ValidationError is returned when a request is invalid.
+type ValidationError struct {
+	// Field is the name of the invalid field.
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + " is invalid"
+}
+
+// GreetRequest is the request object for GreeterService.Greet.
+type GreetRequest struct {
+	// Name is the name of the person to greet.
+	Name string
+}
+
+// GreetResponse is the response object for GreeterService.Greet.
+type GreetResponse struct {
+	// Greeting is the greeting.
+	Greeting string
+}
+
+// FarewellRequest is the request object for GreeterService.Farewell.
+type FarewellRequest struct{}
+
+// FarewellResponse is the response object for GreeterService.Farewell.
+type FarewellResponse struct{}
+
+// WaveRequest is the request object for GreeterService.Wave.
+type WaveRequest struct{}
+
+// WaveResponse is the response object for GreeterService.Wave.
+type WaveResponse struct{}
@@ -0,0 +1,29 @@
+package examplefiles
+
+// GreeterService greets people.
+type GreeterService interface {
+	// Greet greets people.
+	// example_file: "examples/greet.json"
+	// output_example_file: "examples/greet_response.json"
+	Greet(GreetRequest) GreetResponse
+	// Wave waves at people.
+	Wave(WaveRequest) WaveResponse
+}
+
+// GreetRequest is the request object for GreeterService.Greet.
+type GreetRequest struct {
+	// Names are the names of the people to greet.
+	Names []string
+}
+
+// GreetResponse is the response object for GreeterService.Greet.
+type GreetResponse struct {
+	// Greeting is the greeting.
+	Greeting string
+}
+
+// WaveRequest is the request object for GreeterService.Wave.
+type WaveRequest struct{}
+
+// WaveResponse is the response object for GreeterService.Wave.
+type WaveResponse struct{}
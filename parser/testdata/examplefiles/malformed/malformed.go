@@ -0,0 +1,14 @@
+package malformed
+
+// GreeterService greets people.
+type GreeterService interface {
+	// Greet greets people.
+	// example_file: "examples/invalid.json"
+	Greet(GreetRequest) GreetResponse
+}
+
+// GreetRequest is the request object for GreeterService.Greet.
+type GreetRequest struct{}
+
+// GreetResponse is the response object for GreeterService.Greet.
+type GreetResponse struct{}
@@ -0,0 +1,30 @@
+package exampletags
+
+// ProductService manages products.
+type ProductService interface {
+	// Create makes a new product.
+	Create(CreateRequest) CreateResponse
+}
+
+// CreateRequest is the request object for ProductService.Create.
+type CreateRequest struct {
+	// Name is the name of the product.
+	Name string `json:"name" example:"Widget"`
+	// Stock is the number of products available.
+	Stock int `json:"stock" example:"42"`
+	// Price is the price in dollars.
+	Price float64 `json:"price" example:"9.99"`
+	// Active is whether the product can be sold.
+	Active bool `json:"active" example:"true"`
+	// Tags are labels for the product.
+	Tags []string `json:"tags" example:"[\"new\",\"sale\"]"`
+	// Description is the description of the product.
+	// example: "From the comment"
+	Description string `json:"description" example:"From the tag"`
+}
+
+// CreateResponse is the response object for ProductService.Create.
+type CreateResponse struct {
+	// ID is the ID of the new product.
+	ID string `json:"id"`
+}
@@ -0,0 +1,29 @@
+package exclusions
+
+// AccountService manages accounts.
+type AccountService interface {
+	// GetAccount gets an account.
+	GetAccount(GetAccountRequest) GetAccountResponse
+}
+
+// GetAccountRequest is the request object for AccountService.GetAccount.
+type GetAccountRequest struct {
+	// AccountID is the ID of the account to get.
+	// example: "account-123"
+	AccountID string
+}
+
+// GetAccountResponse is the response object for AccountService.GetAccount.
+type GetAccountResponse struct {
+	// Name is the name of the account.
+	// example: "Pace"
+	Name string
+	// ServerNotes are notes only the server needs.
+	// example: "server-notes"
+	// exclude_in: ["typescript"]
+	ServerNotes string
+	// Internal is never exposed.
+	// example: "internal"
+	// exclude: true
+	Internal string
+}
@@ -0,0 +1,49 @@
+package graphql
+
+import "time"
+
+// PostService manages posts.
+type PostService interface {
+	// Create makes a new post.
+	Create(CreateRequest) CreateResponse
+	// List gets all posts.
+	List(ListRequest) ListResponse
+	// Restore puts back a post returned by Create.
+	Restore(CreateResponse) CreateResponse
+}
+
+// Post is a blog post.
+type Post struct {
+	Title string
+	// Kind is the kind of post.
+	// options: ["text", "image"]
+	Kind      string
+	Tags      []string
+	Published *time.Time
+	Views     int
+	Likes     int32
+	Score     float64
+	Draft     bool
+	Attrs     map[string]string
+	Related   []*Post
+}
+
+// CreateRequest is the input for Create.
+type CreateRequest struct {
+	Post Post
+	// Notify sets whether to notify followers.
+	Notify *bool
+}
+
+// CreateResponse is the output for Create.
+type CreateResponse struct {
+	Post *Post
+}
+
+// ListRequest is the input for List.
+type ListRequest struct{}
+
+// ListResponse is the output for List.
+type ListResponse struct {
+	Posts []Post
+}
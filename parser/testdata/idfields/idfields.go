@@ -0,0 +1,28 @@
+package idfields
+
+// UserService manages users.
+type UserService interface {
+	Get(GetRequest) GetResponse
+}
+
+// GetRequest is the input for Get.
+type GetRequest struct {
+	// UserID is the user to get.
+	// id: true
+	UserID string
+	// ID is the ID of the request.
+	ID string
+}
+
+// GetResponse is the output for Get.
+type GetResponse struct {
+	User User
+}
+
+// User is a person who uses the system.
+type User struct {
+	// ID identifies the user.
+	ID string
+	// Name is the name of the user.
+	Name string
+}
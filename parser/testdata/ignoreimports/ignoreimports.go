@@ -0,0 +1,41 @@
+package ignoreimports
+
+import (
+	"context"
+
+	"github.com/pacedotdev/oto/testdata/services"
+)
+
+// TaskService runs tasks.
+type TaskService interface {
+	// Run runs a task.
+	Run(RunRequest) RunResponse
+	// Schedule schedules a task to run later.
+	Schedule(context.Context, ScheduleRequest) ScheduleResponse
+}
+
+// RunRequest is the request object for TaskService.Run.
+type RunRequest struct {
+	// Ctx is the context for the task.
+	Ctx context.Context
+	// Page is the page of tasks to run.
+	Page services.Page
+}
+
+// RunResponse is the response object for TaskService.Run.
+type RunResponse struct {
+	// Count is the number of tasks that ran.
+	Count int
+}
+
+// ScheduleRequest is the request object for TaskService.Schedule.
+type ScheduleRequest struct {
+	// Name is the name of the task.
+	Name string
+}
+
+// ScheduleResponse is the response object for TaskService.Schedule.
+type ScheduleResponse struct {
+	// ID is the ID of the scheduled task.
+	ID string
+}
@@ -0,0 +1,27 @@
+package immutable
+
+// PaymentService takes payments.
+type PaymentService interface {
+	Charge(ChargeRequest) ChargeResponse
+}
+
+// ChargeRequest is the input for Charge.
+type ChargeRequest struct {
+	// Amount is how much to charge.
+	Amount Money
+}
+
+// ChargeResponse is the output for Charge.
+type ChargeResponse struct {
+	// Charged is how much was charged.
+	Charged Money
+}
+
+// Money is an amount of a currency.
+// immutable: true
+type Money struct {
+	// Currency is the ISO 4217 currency code.
+	Currency string
+	// Units is the amount in the smallest unit of the currency.
+	Units int
+}
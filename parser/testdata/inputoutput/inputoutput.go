@@ -0,0 +1,18 @@
+package inputoutput
+
+// SettingsService manages settings.
+type SettingsService interface {
+	// Update saves the settings, and returns them as they were saved.
+	Update(Settings) Settings
+	// Reset restores the default settings.
+	Reset(ResetRequest) Settings
+}
+
+// Settings are the user's preferences.
+type Settings struct {
+	// Theme is the color scheme.
+	Theme string
+}
+
+// ResetRequest is the request object for SettingsService.Reset.
+type ResetRequest struct{}
@@ -0,0 +1,33 @@
+package maps
+
+// StatsService provides statistics.
+type StatsService interface {
+	// GetStats gets statistics.
+	GetStats(GetStatsRequest) GetStatsResponse
+}
+
+// GetStatsRequest is the request object for StatsService.GetStats.
+type GetStatsRequest struct {
+	// Filters are the filters to apply.
+	Filters map[string]string
+}
+
+// GetStatsResponse is the response object for StatsService.GetStats.
+type GetStatsResponse struct {
+	// Counts are the counts by name.
+	Counts map[string]int
+	// Batches are a list of counts by name.
+	Batches []map[string]int
+	// Groups are lists of counts by name.
+	Groups map[string][]int
+	// Greetings are greetings by name.
+	Greetings map[string]*Greeting
+	// Extra is any extra data.
+	Extra map[string]interface{}
+}
+
+// Greeting contains the pleasantry.
+type Greeting struct {
+	// Text is the message.
+	Text string
+}
@@ -0,0 +1,64 @@
+package modules
+
+// InvoiceService manages invoices.
+// module: "billing"
+type InvoiceService interface {
+	// Send sends an invoice.
+	Send(SendInvoiceRequest) SendInvoiceResponse
+}
+
+// PaymentService takes payments.
+// module: "billing"
+type PaymentService interface {
+	// Pay pays an invoice.
+	Pay(PayRequest) PayResponse
+}
+
+// UserService manages users.
+// module: "accounts"
+type UserService interface {
+	// Delete deletes a user.
+	Delete(DeleteUserRequest) DeleteUserResponse
+}
+
+// StatusService reports the health of the system.
+type StatusService interface {
+	// Check checks the system is working.
+	Check(CheckRequest) CheckResponse
+}
+
+// SendInvoiceRequest is the request object for InvoiceService.Send.
+type SendInvoiceRequest struct {
+	// InvoiceID is the ID of the invoice to send.
+	InvoiceID string
+}
+
+// SendInvoiceResponse is the response object for InvoiceService.Send.
+type SendInvoiceResponse struct{}
+
+// PayRequest is the request object for PaymentService.Pay.
+type PayRequest struct {
+	// InvoiceID is the ID of the invoice to pay.
+	InvoiceID string
+}
+
+// PayResponse is the response object for PaymentService.Pay.
+type PayResponse struct{}
+
+// DeleteUserRequest is the request object for UserService.Delete.
+type DeleteUserRequest struct {
+	// UserID is the ID of the user to delete.
+	UserID string
+}
+
+// DeleteUserResponse is the response object for UserService.Delete.
+type DeleteUserResponse struct{}
+
+// CheckRequest is the request object for StatusService.Check.
+type CheckRequest struct{}
+
+// CheckResponse is the response object for StatusService.Check.
+type CheckResponse struct {
+	// OK is true if the system is working.
+	OK bool
+}
@@ -0,0 +1,27 @@
+package namecases
+
+// PageService serves pages.
+type PageService interface {
+	GetHtmlPage(GetHtmlPageRequest) GetHtmlPageResponse
+}
+
+// GetHtmlPageRequest is the input for GetHtmlPage.
+type GetHtmlPageRequest struct {
+	// PageId is the page to get.
+	PageId string
+	// UserID is the user viewing the page.
+	UserID string
+}
+
+// GetHtmlPageResponse is the output for GetHtmlPage.
+type GetHtmlPageResponse struct {
+	Page Page
+}
+
+// Page is a web page.
+type Page struct {
+	// Title is the title of the page.
+	Title string
+	// URL is where the page lives.
+	URL string
+}
@@ -0,0 +1,37 @@
+package nullable
+
+// ProfileService manages profiles.
+type ProfileService interface {
+	// Update updates a profile.
+	Update(UpdateRequest) UpdateResponse
+}
+
+// UpdateRequest is the request object for ProfileService.Update.
+type UpdateRequest struct {
+	// Name is the required name.
+	Name string
+	// Nickname is the nickname, or null to remove it.
+	Nickname *string
+	// Age is the age of the person, or null if it is unknown.
+	// nullable: true
+	Age int
+	// Address is where the person lives, if known.
+	Address *Address
+	// Pets are the pets belonging to the person.
+	Pets []*Pet
+}
+
+// Address is a postal address.
+type Address struct {
+	// Street is the street.
+	Street string
+}
+
+// Pet is a pet.
+type Pet struct {
+	// Name is the name of the pet.
+	Name string
+}
+
+// UpdateResponse is the response object for ProfileService.Update.
+type UpdateResponse struct{}
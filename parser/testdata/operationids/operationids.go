@@ -0,0 +1,34 @@
+package operationids
+
+// GreeterService greets people.
+type GreeterService interface {
+	// Greet greets someone.
+	Greet(GreetRequest) GreetResponse
+	// Farewell says goodbye to someone.
+	// operation_id: "sayGoodbye"
+	Farewell(FarewellRequest) FarewellResponse
+}
+
+// GreetRequest is the request object for GreeterService.Greet.
+type GreetRequest struct {
+	// Name is the name of the person to greet.
+	Name string
+}
+
+// GreetResponse is the response object for GreeterService.Greet.
+type GreetResponse struct {
+	// Greeting is the greeting.
+	Greeting string
+}
+
+// FarewellRequest is the request object for GreeterService.Farewell.
+type FarewellRequest struct {
+	// Name is the name of the person leaving.
+	Name string
+}
+
+// FarewellResponse is the response object for GreeterService.Farewell.
+type FarewellResponse struct {
+	// Farewell is the farewell message.
+	Farewell string
+}
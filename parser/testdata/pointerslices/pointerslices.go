@@ -0,0 +1,29 @@
+package pointerslices
+
+// GreeterService greets people.
+type GreeterService interface {
+	// GetGreetings gets greetings.
+	GetGreetings(GetGreetingsRequest) GetGreetingsResponse
+}
+
+// GetGreetingsRequest is the request object for GreeterService.GetGreetings.
+type GetGreetingsRequest struct {
+	// Names are the names to greet, where null means a stranger.
+	Names []*string
+	// Languages are the languages to greet in.
+	Languages []string
+}
+
+// GetGreetingsResponse is the response object for GreeterService.GetGreetings.
+type GetGreetingsResponse struct {
+	// Greetings are the greetings, or null where none was found.
+	Greetings []*Greeting
+	// Featured is the featured greeting.
+	Featured *Greeting
+}
+
+// Greeting is a greeting.
+type Greeting struct {
+	// Text is the message.
+	Text string
+}
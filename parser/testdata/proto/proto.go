@@ -0,0 +1,62 @@
+package proto
+
+import "time"
+
+// AccountService manages accounts.
+type AccountService interface {
+	// Create makes a new account.
+	// It returns the new account.
+	Create(CreateRequest) CreateResponse
+	// Get gets an account.
+	Get(GetRequest) GetResponse
+}
+
+// Plan is the account plan.
+type Plan string
+
+const (
+	// PlanFree is the free plan.
+	PlanFree Plan = "free"
+	// PlanPro is the paid plan.
+	PlanPro Plan = "pro"
+)
+
+// Account is a user account.
+type Account struct {
+	// UserID is the ID of the owner.
+	UserID  string
+	Name    string
+	Age     int
+	Score   float64
+	Ratio   float32
+	Small   int8
+	Active  bool
+	Nick    *string
+	Tags    []string
+	Plan    Plan
+	Created time.Time
+	Limits  map[string]int64
+}
+
+// CreateRequest is the input for Create.
+type CreateRequest struct {
+	Account Account
+}
+
+// CreateResponse is the output for Create.
+type CreateResponse struct {
+	Account  *Account
+	Friends  []Account
+	ByName   map[string]Account
+	Versions []uint32
+}
+
+// GetRequest is the input for Get.
+type GetRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// GetResponse is the output for Get.
+type GetResponse struct {
+	Account Account
+}
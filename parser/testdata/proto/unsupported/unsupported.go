@@ -0,0 +1,15 @@
+package unsupported
+
+// SettingsService manages settings.
+type SettingsService interface {
+	Save(SaveRequest) SaveResponse
+}
+
+// SaveRequest is the input for Save.
+type SaveRequest struct {
+	Key   string
+	Value interface{}
+}
+
+// SaveResponse is the output for Save.
+type SaveResponse struct{}
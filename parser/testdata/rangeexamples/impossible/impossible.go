@@ -0,0 +1,20 @@
+package impossible
+
+// SizesService checks the examples for each integer size.
+type SizesService interface {
+	Check(CheckRequest) CheckResponse
+}
+
+// CheckRequest is the input for Check.
+type CheckRequest struct {
+	// Negative is an unsigned number with a range it cannot hold.
+	// min: -10
+	// max: -1
+	Negative uint
+}
+
+// CheckResponse is the output for Check.
+type CheckResponse struct {
+	// OK is true if the check passed.
+	OK bool
+}
@@ -0,0 +1,74 @@
+package rangeexamples
+
+// SearchService searches things.
+type SearchService interface {
+	Search(SearchRequest) SearchResponse
+}
+
+// SearchRequest is the input for Search.
+type SearchRequest struct {
+	// Limit is the maximum number of results.
+	// max: 10
+	Limit int
+	// Page is the page to get.
+	// min: 1
+	Page int
+	// Offset is the number of results to skip.
+	// min: 500
+	// max: 1000
+	Offset int
+	// Score is the minimum score.
+	// min: 2
+	// max: 5
+	Score float64
+	// Count is the number of things.
+	// max: 10
+	// example: 5
+	Count int
+	// Total has no range.
+	Total int
+}
+
+// SearchResponse is the output for Search.
+type SearchResponse struct {
+	// Query is the query that was searched for.
+	Query string
+}
+
+// SizesService checks the examples for each integer size.
+type SizesService interface {
+	Check(CheckRequest) CheckResponse
+}
+
+// CheckRequest is the input for Check.
+type CheckRequest struct {
+	// Tiny is a small unsigned number.
+	// max: 1000
+	Tiny uint8
+	// Negative is an unsigned number with a mostly negative range.
+	// min: -10
+	// max: 0
+	Negative uint
+	// Small is a 16 bit number.
+	// min: 0
+	Small int16
+	// Short is a 16 bit number with a range reaching past the type.
+	// min: 30000
+	// max: 40000
+	Short int16
+	// Big is a 64 bit number.
+	// max: 1000000
+	Big int64
+	// Width is an 8 bit unsigned number with no range.
+	Width uint8
+	// Height is a 16 bit number with no range.
+	Height int16
+	// Length is a 64 bit number with no range.
+	Length int64
+}
+
+// CheckResponse is the output for Check.
+type CheckResponse struct {
+	// OK is true if the check passed.
+	OK bool
+}
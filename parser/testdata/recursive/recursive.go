@@ -0,0 +1,38 @@
+package recursive
+
+// CommentService manages comments.
+type CommentService interface {
+	GetThread(GetThreadRequest) GetThreadResponse
+}
+
+// GetThreadRequest is the input for GetThread.
+type GetThreadRequest struct {
+	// ThreadID is the thread to get.
+	ThreadID string
+}
+
+// GetThreadResponse is the output for GetThread.
+type GetThreadResponse struct {
+	// Comments are the top level comments.
+	Comments []*Comment
+}
+
+// Comment is a comment in a thread, which may have replies.
+type Comment struct {
+	// Text is the comment.
+	Text string
+	// Replies are comments that reply to this one.
+	Replies []*Comment
+	// Parent is the comment this one replies to, if any.
+	Parent *Comment
+	// Author is who wrote the comment.
+	Author Author
+}
+
+// Author is a person who writes comments.
+type Author struct {
+	// Name is the name of the author.
+	Name string
+	// Pinned are the comments the author has pinned.
+	Pinned []Comment
+}
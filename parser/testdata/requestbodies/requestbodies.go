@@ -0,0 +1,36 @@
+package requestbodies
+
+// LibraryService lists things in the library.
+type LibraryService interface {
+	// ListBooks lists a page of books.
+	ListBooks(PageRequest) ListBooksResponse
+	// ListAuthors lists a page of authors.
+	ListAuthors(PageRequest) ListAuthorsResponse
+	// UploadBooks uploads a page of books from a CSV file.
+	// requestContentType: "text/csv"
+	UploadBooks(PageRequest) ListBooksResponse
+}
+
+// PageRequest is the request object for paginated methods.
+type PageRequest struct {
+	// Page is the page to get.
+	// example: 1
+	Page int
+	// PageSize is the number of items on each page.
+	// example: 20
+	PageSize int
+}
+
+// ListBooksResponse is a page of books.
+type ListBooksResponse struct {
+	// Titles are the titles of the books.
+	// example: ["Dune"]
+	Titles []string
+}
+
+// ListAuthorsResponse is a page of authors.
+type ListAuthorsResponse struct {
+	// Names are the names of the authors.
+	// example: ["Frank Herbert"]
+	Names []string
+}
@@ -0,0 +1,41 @@
+package shared
+
+// SearchService searches everything.
+type SearchService interface {
+	// Search finds matching items.
+	Search(QueryRequest) SearchResponse
+}
+
+// SuggestService suggests search terms.
+type SuggestService interface {
+	// Suggest suggests search terms as people type.
+	Suggest(QueryRequest) SuggestResponse
+}
+
+// InternalService is not exposed.
+type InternalService interface {
+	// Reindex rebuilds the search index.
+	Reindex(QueryRequest) ReindexResponse
+}
+
+// QueryRequest is the request object shared by SearchService and
+// SuggestService.
+type QueryRequest struct {
+	// Query is the search text.
+	Query string
+}
+
+// SearchResponse is the response object for SearchService.Search.
+type SearchResponse struct {
+	// Results are the matching items.
+	Results []string
+}
+
+// SuggestResponse is the response object for SuggestService.Suggest.
+type SuggestResponse struct {
+	// Suggestions are the suggested search terms.
+	Suggestions []string
+}
+
+// ReindexResponse is the response object for InternalService.Reindex.
+type ReindexResponse struct{}
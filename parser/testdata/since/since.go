@@ -0,0 +1,22 @@
+package since
+
+// ProfileService manages profiles.
+type ProfileService interface {
+	// Update changes a profile.
+	Update(UpdateRequest) UpdateResponse
+}
+
+// UpdateRequest is the input for Update.
+type UpdateRequest struct {
+	// Name is the display name.
+	Name string
+	// Pronouns are the preferred pronouns.
+	// since: "v1.2"
+	Pronouns string
+}
+
+// UpdateResponse is the output for Update.
+type UpdateResponse struct {
+	// since: "v1.3"
+	Version int
+}
@@ -0,0 +1,16 @@
+package malformed
+
+// SearchService searches things.
+type SearchService interface {
+	// Search finds things.
+	// sla_ms: 0.5
+	Search(SearchRequest) SearchResponse
+}
+
+type SearchRequest struct {
+	Query string
+}
+
+type SearchResponse struct {
+	Results []string
+}
@@ -0,0 +1,33 @@
+package sla
+
+// SearchService searches things.
+type SearchService interface {
+	// Search finds things.
+	// sla_ms: 200
+	Search(SearchRequest) SearchResponse
+	// Reindex rebuilds the search index.
+	// sla_ms: 5000
+	Reindex(ReindexRequest) ReindexResponse
+	// Suggest suggests queries.
+	Suggest(SuggestRequest) SuggestResponse
+}
+
+type SearchRequest struct {
+	Query string
+}
+
+type SearchResponse struct {
+	Results []string
+}
+
+type ReindexRequest struct{}
+
+type ReindexResponse struct{}
+
+type SuggestRequest struct {
+	Prefix string
+}
+
+type SuggestResponse struct {
+	Suggestions []string
+}
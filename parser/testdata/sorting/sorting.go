@@ -0,0 +1,48 @@
+package sorting
+
+// OrderService manages orders.
+type OrderService interface {
+	// Get gets a single order.
+	Get(GetOrderRequest) GetOrderResponse
+	// List gets a page of orders.
+	// default_sort: "created_at desc"
+	List(ListOrdersRequest) ListOrdersResponse
+}
+
+// GetOrderRequest is the request object for OrderService.Get.
+type GetOrderRequest struct {
+	// OrderID is the ID of the order to get.
+	OrderID string
+}
+
+// GetOrderResponse is the response object for OrderService.Get.
+type GetOrderResponse struct {
+	// Order is the order.
+	Order Order
+}
+
+// ListOrdersRequest is the request object for OrderService.List.
+type ListOrdersRequest struct {
+	// Cursor is where to start the page from.
+	Cursor string
+	// Limit is the maximum number of orders to get.
+	Limit int
+	// Sort is the order to return the orders in.
+	Sort string
+}
+
+// ListOrdersResponse is the response object for OrderService.List.
+type ListOrdersResponse struct {
+	// Orders are the orders in this page.
+	Orders []Order
+	// NextCursor gets the next page.
+	NextCursor string
+	// Total is the number of orders across all pages.
+	Total int
+}
+
+// Order is an order placed by a customer.
+type Order struct {
+	// ID is the unique identifier of the order.
+	ID string
+}
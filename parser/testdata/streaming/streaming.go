@@ -0,0 +1,51 @@
+package streaming
+
+// PriceService provides prices.
+type PriceService interface {
+	// Quote gets the current price.
+	Quote(QuoteRequest) QuoteResponse
+	// Watch sends price changes as they happen.
+	Watch(WatchRequest) <-chan PriceChange
+}
+
+// QuoteRequest is the request object for PriceService.Quote.
+type QuoteRequest struct {
+	// Symbol is the ticker symbol.
+	// example: "ACME"
+	Symbol string
+}
+
+// QuoteResponse is the response object for PriceService.Quote.
+type QuoteResponse struct {
+	// Price is the current price.
+	// example: 101.5
+	Price float64
+}
+
+// WatchRequest is the request object for PriceService.Watch.
+type WatchRequest struct {
+	// Symbols are the ticker symbols to watch.
+	// example: ["ACME"]
+	Symbols []string
+}
+
+// PriceChange describes a change in price.
+type PriceChange struct {
+	// Symbol is the ticker symbol.
+	// example: "ACME"
+	Symbol string
+	// Price is the new price.
+	// example: 102.25
+	Price float64
+	// Trades are the trades that caused the change.
+	Trades []Trade
+	// Volumes are the volumes traded by exchange.
+	Volumes map[string][]int
+}
+
+// Trade is a single trade.
+type Trade struct {
+	// Quantity is the number traded.
+	// example: 10
+	Quantity int
+}
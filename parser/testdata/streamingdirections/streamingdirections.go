@@ -0,0 +1,31 @@
+package streamingdirections
+
+// ChatService is a chat server.
+type ChatService interface {
+	// Get gets a single message.
+	Get(Message) Message
+	// Upload receives many messages.
+	Upload(<-chan Message) UploadResponse
+	// Watch sends new messages as they arrive.
+	Watch(WatchRequest) <-chan Message
+	// Chat sends and receives messages.
+	Chat(<-chan Message) <-chan Message
+}
+
+// Message is a chat message.
+type Message struct {
+	// Text is the content of the message.
+	Text string
+}
+
+// UploadResponse is the response object for ChatService.Upload.
+type UploadResponse struct {
+	// Count is the number of messages received.
+	Count int
+}
+
+// WatchRequest is the request object for ChatService.Watch.
+type WatchRequest struct {
+	// Room is the room to watch.
+	Room string
+}
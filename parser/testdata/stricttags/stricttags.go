@@ -0,0 +1,20 @@
+package stricttags
+
+// ProfileService manages profiles.
+type ProfileService interface {
+	// Update updates a profile.
+	Update(UpdateRequest) UpdateResponse
+}
+
+// UpdateRequest is the request object for ProfileService.Update.
+type UpdateRequest struct {
+	// Name is the name of the person.
+	Name string `json:"name,omitempty"`
+	// Age is the age of the person.
+	Age int `json:"age,string"`
+	// Nickname is the nickname of the person.
+	Nickname string `json:"nickname,omitemtpy"`
+}
+
+// UpdateResponse is the response object for ProfileService.Update.
+type UpdateResponse struct{}
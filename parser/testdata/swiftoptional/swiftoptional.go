@@ -0,0 +1,34 @@
+package swiftoptional
+
+// ProfileService manages profiles.
+type ProfileService interface {
+	// Update changes a profile.
+	Update(UpdateRequest) UpdateResponse
+}
+
+// UpdateRequest is the request object for ProfileService.Update.
+type UpdateRequest struct {
+	// Name is the display name.
+	Name string
+	// Nicknames are other names.
+	// swift_optional: "explicit"
+	Nicknames []string
+	// Profile is the new profile.
+	Profile *Profile
+}
+
+// UpdateResponse is the response object for ProfileService.Update.
+// swift_optional: "explicit"
+type UpdateResponse struct {
+	// Profile is the updated profile.
+	Profile Profile
+	// Version is the new version of the profile.
+	// swift_optional: "implicit"
+	Version int
+}
+
+// Profile describes a person.
+type Profile struct {
+	// Name is the display name.
+	Name string
+}
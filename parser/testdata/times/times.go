@@ -0,0 +1,27 @@
+package times
+
+import "time"
+
+// EventService manages events.
+type EventService interface {
+	// CreateEvent creates an event.
+	CreateEvent(CreateEventRequest) CreateEventResponse
+}
+
+// CreateEventRequest is the request object for EventService.CreateEvent.
+type CreateEventRequest struct {
+	// StartsAt is when the event starts.
+	StartsAt time.Time
+	// Day is the day of the event.
+	// format: "date"
+	Day time.Time
+	// EndsAt is when the event ends.
+	// example: "2022-03-04T05:06:07Z"
+	EndsAt *time.Time
+}
+
+// CreateEventResponse is the response object for EventService.CreateEvent.
+type CreateEventResponse struct {
+	// ID is the ID of the new event.
+	ID string
+}
@@ -0,0 +1,30 @@
+package transforms
+
+// AccountService manages accounts.
+type AccountService interface {
+	SignUp(SignUpRequest) SignUpResponse
+}
+
+// SignUpRequest is the input for SignUp.
+type SignUpRequest struct {
+	// Name is the name of the person.
+	// transform: "trim"
+	Name string
+	// Email is the email address of the person.
+	// transform: "lowercase"
+	Email string
+	// Code is the invite code.
+	// transform: "uppercase"
+	Code string
+	// Nickname is what to call the person.
+	// transform: "reverse"
+	Nickname string
+	// Bio is about the person.
+	Bio string
+}
+
+// SignUpResponse is the output for SignUp.
+type SignUpResponse struct {
+	// OK is true if the account was created.
+	OK bool
+}
@@ -0,0 +1,30 @@
+package typealiases
+
+// GreeterService is a polite API.
+type GreeterService interface {
+	// Greet creates a Greeting.
+	Greet(GreetRequest) GreetResponse
+}
+
+// GreetRequest is the request object for GreeterService.Greet.
+type GreetRequest struct {
+	// Name is the name of the person to greet.
+	Name string
+}
+
+// GreetResponse is the response object for GreeterService.Greet.
+type GreetResponse struct {
+	// Greeting is the greeting.
+	Greeting Hello
+	// Previous are the previous greetings.
+	Previous []*Hello
+}
+
+// Greeting contains the pleasantry.
+type Greeting struct {
+	// Text is the message.
+	Text string
+}
+
+// Hello is an alias for Greeting.
+type Hello = Greeting
@@ -0,0 +1,30 @@
+package unknownkeys
+
+// SettingsService stores settings.
+type SettingsService interface {
+	// Save saves the settings.
+	Save(SaveRequest) SaveResponse
+}
+
+// SaveRequest is the request object for SettingsService.Save.
+// unknown_keys: "strict"
+type SaveRequest struct {
+	// Settings are the settings to save.
+	Settings Settings
+}
+
+// Settings are user preferences, which may include keys
+// from newer clients.
+// unknown_keys: "passthrough"
+type Settings struct {
+	// Theme is the name of the color theme.
+	// example: "dark"
+	Theme string
+}
+
+// SaveResponse is the response object for SettingsService.Save.
+type SaveResponse struct {
+	// Saved is true if the settings were saved.
+	// example: true
+	Saved bool
+}
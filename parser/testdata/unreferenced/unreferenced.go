@@ -0,0 +1,33 @@
+package unreferenced
+
+// GreeterService greets people.
+type GreeterService interface {
+	Greet(GreetRequest) GreetResponse
+}
+
+// GreetRequest is the input for Greet.
+type GreetRequest struct {
+	Name string
+}
+
+// GreetResponse is the output for Greet.
+type GreetResponse struct {
+	Greeting Greeting
+}
+
+// Greeting is a nice message.
+type Greeting struct {
+	Text string
+	// Language is referenced through Greeting.
+	Language Language
+}
+
+// Language is a spoken language.
+type Language struct {
+	Code string
+}
+
+// Farewell is not used by any method.
+type Farewell struct {
+	Text string
+}
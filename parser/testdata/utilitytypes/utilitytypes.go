@@ -0,0 +1,48 @@
+package utilitytypes
+
+// UserService manages users.
+type UserService interface {
+	// Rename changes the name of a user.
+	Rename(RenameRequest) RenameResponse
+	// Lookup finds a user.
+	Lookup(LookupRequest) LookupResponse
+}
+
+// User is a person who uses the system.
+type User struct {
+	// ID is the unique identifier of the user.
+	ID string
+	// Name is the display name of the user.
+	Name string
+	// Email is the email address of the user.
+	Email string
+}
+
+// RenameRequest is the request object for UserService.Rename.
+// from: "User"
+// pick: ["id", "name"]
+type RenameRequest struct {
+	// ID is the unique identifier of the user.
+	ID string
+	// Name is the new display name of the user.
+	Name string
+}
+
+// RenameResponse is the response object for UserService.Rename.
+type RenameResponse struct{}
+
+// LookupRequest is the request object for UserService.Lookup.
+// from: "User"
+// omit: ["id"]
+type LookupRequest struct {
+	// Name is the display name of the user.
+	Name string
+	// Email is the email address of the user.
+	Email string
+}
+
+// LookupResponse is the response object for UserService.Lookup.
+type LookupResponse struct {
+	// User is the user that was found.
+	User User
+}
@@ -0,0 +1,32 @@
+package valuepointers
+
+// SettingsService manages settings.
+type SettingsService interface {
+	Update(UpdateRequest) UpdateResponse
+}
+
+// UpdateRequest is the input for Update.
+type UpdateRequest struct {
+	// Limit is the new limit, or null to leave it unchanged.
+	Limit *int
+	// Enabled is whether the settings are enabled.
+	Enabled *bool
+	// Weights are the optional weights.
+	Weights []*float64
+	// Label is the optional label.
+	Label *string
+	// Owner is the optional owner.
+	Owner *Owner
+}
+
+// Owner owns settings.
+type Owner struct {
+	// Name is the name of the owner.
+	Name string
+}
+
+// UpdateResponse is the output for Update.
+type UpdateResponse struct {
+	// Version is the new version.
+	Version uint32
+}
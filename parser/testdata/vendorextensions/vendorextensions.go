@@ -0,0 +1,24 @@
+package vendorextensions
+
+// OrderService manages orders.
+type OrderService interface {
+	// PlaceOrder places an order.
+	// x-internal-id: 42
+	// x-rate-limit: {"perMinute": 10}
+	PlaceOrder(PlaceOrderRequest) PlaceOrderResponse
+}
+
+// PlaceOrderRequest is the request object for OrderService.PlaceOrder.
+// x-entity: "order"
+type PlaceOrderRequest struct {
+	// ProductID is the ID of the product to order.
+	// example: "product-123"
+	ProductID string
+}
+
+// PlaceOrderResponse is the response object for OrderService.PlaceOrder.
+type PlaceOrderResponse struct {
+	// OrderID is the ID of the new order.
+	// example: "order-123"
+	OrderID string
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TypeScriptDeclarations generates TypeScript declarations for the
+// enums and objects, suitable for a .d.ts file.
+// Objects become interfaces and enums become union types of their
+// values. There are no imports and no runtime code, so the output
+// has no dependencies.
+func (d *Definition) TypeScriptDeclarations() template.HTML {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by oto; DO NOT EDIT.")
+	for _, enum := range d.Enums {
+		fmt.Fprintln(&buf)
+		writeTSDoc(&buf, enum.Comment, "")
+		values := make([]string, len(enum.Values))
+		for i := range enum.Values {
+			if value, ok := enum.Values[i].Value.(string); ok {
+				values[i] = strconv.Quote(value)
+				continue
+			}
+			values[i] = fmt.Sprint(enum.Values[i].Value)
+		}
+		if len(values) == 0 {
+			values = []string{"never"}
+		}
+		fmt.Fprintf(&buf, "export type %s = %s;\n", enum.Name, strings.Join(values, " | "))
+	}
+	for _, object := range d.Objects {
+		fmt.Fprintln(&buf)
+		writeTSDoc(&buf, object.Comment, "")
+		fmt.Fprintf(&buf, "export interface %s {\n", object.Name)
+		for _, field := range object.Fields {
+			if field.IsExcludedIn("typescript") {
+				continue
+			}
+			writeTSDoc(&buf, field.Comment, "\t")
+			optional := ""
+			if field.OmitEmpty || field.Optional {
+				optional = "?"
+			}
+			fmt.Fprintf(&buf, "\t%s%s: %s;\n", field.NameLowerCamel, optional, tsDeclarationType(field.Type))
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+	return template.HTML(buf.String())
+}
+
+// tsDeclarationType gets the TypeScript type for a field of type
+// ftype. Enums are referred to by name, and pointers may be null.
+func tsDeclarationType(ftype FieldType) string {
+	typ := ftype.TSType
+	switch {
+	case ftype.IsEnum:
+		typ = ftype.CleanObjectName
+	case ftype.TSType == "object":
+		typ = "Record<string, any>"
+	}
+	if ftype.Multiple {
+		if ftype.ElementIsPointer {
+			return "(" + typ + " | null)[]"
+		}
+		return typ + "[]"
+	}
+	if ftype.IsOptional() {
+		return typ + " | null"
+	}
+	return typ
+}
+
+// writeTSDoc writes comment as a /** */ doc comment.
+func writeTSDoc(w io.Writer, comment, indent string) {
+	if comment == "" {
+		return
+	}
+	if !strings.Contains(comment, "\n") {
+		fmt.Fprintf(w, "%s/** %s */\n", indent, comment)
+		return
+	}
+	fmt.Fprintf(w, "%s/**\n", indent)
+	s := bufio.NewScanner(strings.NewReader(comment))
+	for s.Scan() {
+		fmt.Fprintf(w, "%s * %s\n", indent, s.Text())
+	}
+	fmt.Fprintf(w, "%s */\n", indent)
+}
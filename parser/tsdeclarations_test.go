@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTypeScriptDeclarations(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	s := string(def.TypeScriptDeclarations())
+	should := `/**
+ * GreetResponse is the response object containing a
+ * person's greeting.
+ */
+export interface GreetResponse {
+	/** Greeting is the greeted person's Greeting. */
+	greeting: Greeting | null;
+	/** Error is string explaining what went wrong. Empty if everything was fine. */
+	error?: string;
+}
+`
+	if !strings.Contains(s, should) {
+		t.Errorf("missing: %s\n\ngot: %s", should, s)
+	}
+	is.True(!strings.Contains(s, "import"))
+	is.True(!strings.Contains(strings.ToLower(s), "zod"))
+}
+
+func TestTypeScriptDeclarationsEnumsAndMaps(t *testing.T) {
+	is := is.New(t)
+	def, err := New("./testdata/enums").Parse()
+	is.NoErr(err)
+	s := string(def.TypeScriptDeclarations())
+	is.True(strings.Contains(s, `export type Color = "red" | "green";`))
+
+	def, err = New("./testdata/maps").Parse()
+	is.NoErr(err)
+	s = string(def.TypeScriptDeclarations())
+	is.True(strings.Contains(s, "Record<string, "))
+}
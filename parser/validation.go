@@ -0,0 +1,71 @@
+package parser
+
+// validationMetadataKeys are the metadata keys that describe how
+// a field should be validated.
+var validationMetadataKeys = []string{
+	"required",
+	"min",
+	"max",
+	"min_length",
+	"max_length",
+	"pattern",
+	"options",
+}
+
+// HookInfo describes the validation that should happen for a
+// method's input object.
+type HookInfo struct {
+	// Service is the name of the service.
+	Service string `json:"service"`
+	// Method is the name of the method.
+	Method string `json:"method"`
+	// InputObjectName is the name of the input object.
+	InputObjectName string `json:"inputObjectName"`
+	// FuncName is a suggested name for the validation function,
+	// for example validateGreetRequest.
+	FuncName string `json:"funcName"`
+	// Fields are the fields of the input object that carry
+	// validation metadata.
+	Fields []Field `json:"fields"`
+}
+
+// ValidationHooks gets a HookInfo for each method, so generators can
+// emit validation stubs.
+// Validation metadata are required, min, max, min_length, max_length,
+// pattern, and options.
+// Methods whose input object is not in the definition get a HookInfo
+// with no fields.
+func (d *Definition) ValidationHooks() []HookInfo {
+	var hooks []HookInfo
+	for _, service := range d.Services {
+		for _, method := range service.Methods {
+			inputObjectName := method.InputObject.CleanObjectName
+			hook := HookInfo{
+				Service:         service.Name,
+				Method:          method.Name,
+				InputObjectName: inputObjectName,
+				FuncName:        "validate" + inputObjectName,
+			}
+			if inputObject, err := d.Object(inputObjectName); err == nil {
+				for _, field := range inputObject.Fields {
+					if hasValidationMetadata(field) {
+						hook.Fields = append(hook.Fields, field)
+					}
+				}
+			}
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// hasValidationMetadata gets whether field has any of the
+// validationMetadataKeys in its metadata.
+func hasValidationMetadata(field Field) bool {
+	for _, key := range validationMetadataKeys {
+		if _, ok := field.Metadata[key]; ok {
+			return true
+		}
+	}
+	return false
+}
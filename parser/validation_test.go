@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestValidationHooks(t *testing.T) {
+	is := is.New(t)
+
+	def := &Definition{
+		Services: []Service{
+			{
+				Name: "GreeterService",
+				Methods: []Method{
+					{
+						Name:        "Greet",
+						InputObject: FieldType{CleanObjectName: "GreetRequest"},
+					},
+					{
+						Name:        "GetGreetings",
+						InputObject: FieldType{CleanObjectName: "GetGreetingsRequest"},
+					},
+					{
+						Name:        "Wave",
+						InputObject: FieldType{CleanObjectName: "WaveRequest"},
+					},
+				},
+			},
+		},
+		Objects: []Object{
+			{
+				Name: "GreetRequest",
+				Fields: []Field{
+					{
+						Name:     "Name",
+						Metadata: map[string]interface{}{"required": true},
+					},
+					{
+						Name:     "Note",
+						Metadata: map[string]interface{}{},
+					},
+					{
+						Name:     "Times",
+						Metadata: map[string]interface{}{"min": float64(1), "max": float64(10)},
+					},
+				},
+			},
+			{
+				Name: "GetGreetingsRequest",
+				Fields: []Field{
+					{
+						Name:     "Page",
+						Metadata: map[string]interface{}{},
+					},
+				},
+			},
+		},
+	}
+	hooks := def.ValidationHooks()
+	is.Equal(len(hooks), 3)
+	is.Equal(hooks[0].Service, "GreeterService")
+	is.Equal(hooks[0].Method, "Greet")
+	is.Equal(hooks[0].InputObjectName, "GreetRequest")
+	is.Equal(hooks[0].FuncName, "validateGreetRequest")
+	is.Equal(len(hooks[0].Fields), 2)
+	is.Equal(hooks[0].Fields[0].Name, "Name")
+	is.Equal(hooks[0].Fields[1].Name, "Times")
+	is.Equal(hooks[1].Method, "GetGreetings")
+	is.Equal(len(hooks[1].Fields), 0) // nothing to validate
+	is.Equal(hooks[2].Method, "Wave")
+	is.Equal(hooks[2].FuncName, "validateWaveRequest")
+	is.Equal(len(hooks[2].Fields), 0) // WaveRequest isn't in the definition
+}
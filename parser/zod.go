@@ -0,0 +1,20 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// ZodIndexFile generates an index.ts barrel file that re-exports the
+// Zod schemas of every object, when each object's schema is generated
+// into its own <object>.gen.ts file, like Greeting.gen.ts.
+func (d *Definition) ZodIndexFile() template.HTML {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by oto; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	for _, object := range d.Objects {
+		fmt.Fprintf(&buf, "export * from \"./%s.gen\";\n", object.Name)
+	}
+	return template.HTML(buf.String())
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestZodIndexFile(t *testing.T) {
+	is := is.New(t)
+	patterns := []string{"./testdata/services/pleasantries"}
+	parser := New(patterns...)
+	parser.Verbose = testing.Verbose()
+	parser.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := parser.Parse()
+	is.NoErr(err)
+
+	s := string(def.ZodIndexFile())
+	is.True(strings.HasPrefix(s, "// Code generated by oto; DO NOT EDIT.\n\n"))
+	for _, should := range []string{
+		"export * from \"./GreetRequest.gen\";\n",
+		"export * from \"./GreetResponse.gen\";\n",
+		"export * from \"./Greeting.gen\";\n",
+		"export * from \"./Page.gen\";\n", // imported
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s\n\ngot: %s", should, s)
+		}
+	}
+	is.Equal(strings.Count(s, "export * from"), len(def.Objects))
+}
@@ -13,6 +13,9 @@ func ObjectGolang(def parser.Definition, object *parser.Object, tabs int) templa
 	s := &strings.Builder{}
 	fmt.Fprintf(s, "%s{", object.ExternalObjectName)
 	for _, field := range object.Fields {
+		if field.IsExcludedIn("go") {
+			continue
+		}
 		fmt.Fprintf(s, "\n")
 		fmt.Fprint(s, strings.Repeat("\t", tabs+1))
 		if field.Type.IsObject {
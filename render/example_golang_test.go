@@ -37,3 +37,16 @@ func TestExmapleGolang(t *testing.T) {
 		}
 	}
 }
+
+func TestExampleGolangExcludeIn(t *testing.T) {
+	is := is.New(t)
+	parser := parser.New("../parser/testdata/exclusions")
+	parser.Verbose = testing.Verbose()
+	def, err := parser.Parse()
+	is.NoErr(err)
+	outputObject, err := def.Object("GetAccountResponse")
+	is.NoErr(err)
+	example := string(ObjectGolang(def, outputObject, 0))
+	is.True(strings.Contains(example, `ServerNotes: "server-notes",`)) // only excluded in typescript
+	is.True(!strings.Contains(example, "Internal"))                    // excluded everywhere
+}
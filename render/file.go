@@ -0,0 +1,76 @@
+package render
+
+import (
+	"go/format"
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/pacedotdev/oto/parser"
+	"github.com/pkg/errors"
+)
+
+// RenderFile renders the template like Render, and prepares the
+// output to be written to a file.
+// The header (like "// Code generated by oto; DO NOT EDIT.") is
+// added to the top. If it begins with // or #, that prefix is
+// added to any of its other lines that are missing it, so
+// multi-line headers stay comments.
+// The output ends with exactly one newline, and Go source (output
+// starting with a package clause) is formatted with gofmt.
+func RenderFile(template string, def parser.Definition, params map[string]interface{}, header string) (string, error) {
+	out, err := Render(template, def, params)
+	if err != nil {
+		return "", err
+	}
+	if header != "" {
+		out = commentHeader(header) + "\n\n" + strings.TrimLeft(out, "\r\n")
+	}
+	out = strings.TrimRight(out, " \t\r\n") + "\n"
+	if !isGoSource(out) {
+		return out, nil
+	}
+	b, err := format.Source([]byte(out))
+	if err != nil {
+		return "", errors.Wrap(err, "format")
+	}
+	return string(b), nil
+}
+
+// commentHeader makes every line of header a comment, using the
+// // or # prefix from its first line.
+// Headers without either prefix are returned as they are.
+func commentHeader(header string) string {
+	header = strings.TrimRight(header, "\r\n")
+	var prefix string
+	switch {
+	case strings.HasPrefix(header, "//"):
+		prefix = "//"
+	case strings.HasPrefix(header, "#"):
+		prefix = "#"
+	default:
+		return header
+	}
+	lines := strings.Split(header, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, prefix):
+		case strings.TrimSpace(line) == "":
+			lines[i] = prefix
+		default:
+			lines[i] = prefix + " " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isGoSource gets whether src starts with a Go package clause,
+// ignoring any comments before it.
+func isGoSource(src string) bool {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, []byte(src), nil, 0)
+	_, tok, _ := s.Scan()
+	return tok == token.PACKAGE
+}
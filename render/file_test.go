@@ -0,0 +1,30 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/pacedotdev/oto/parser"
+)
+
+func TestRenderFile(t *testing.T) {
+	is := is.New(t)
+	def := parser.Definition{PackageName: "services"}
+	s, err := RenderFile("package <%= def.PackageName %>\nvar   x = 1\n\n\n", def, nil, "// Code generated by oto; DO NOT EDIT.")
+	is.NoErr(err)
+	is.Equal(s, "// Code generated by oto; DO NOT EDIT.\n\npackage services\n\nvar x = 1\n")
+
+	s, err = RenderFile("name: <%= def.PackageName %>", def, nil, "# Code generated by oto.\nDO NOT EDIT.\n")
+	is.NoErr(err)
+	is.Equal(s, "# Code generated by oto.\n# DO NOT EDIT.\n\nname: services\n")
+
+	s, err = RenderFile("name: <%= def.PackageName %>\n\n", def, nil, "")
+	is.NoErr(err)
+	is.Equal(s, "name: services\n")
+}
+
+func TestRenderFileInvalidGo(t *testing.T) {
+	is := is.New(t)
+	_, err := RenderFile("package services\nfunc {", parser.Definition{}, nil, "")
+	is.True(err != nil)
+}
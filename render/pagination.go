@@ -0,0 +1,121 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/pacedotdev/oto/parser"
+	"github.com/pkg/errors"
+)
+
+// PaginationFields are the JSON names of the fields used to page
+// through the results of a list method.
+// Names are empty if the method has no such field.
+type PaginationFields struct {
+	// Page is the input field that selects the page, like a cursor
+	// or page number.
+	Page string
+	// Size is the input field that sets how many items are in a page.
+	Size string
+	// Total is the output field with the total number of items.
+	Total string
+}
+
+// paginationPageFields, paginationSizeFields and paginationTotalFields
+// are the Go field names recognized for pagination, in order of
+// preference.
+var (
+	paginationPageFields  = []string{"Cursor", "PageToken", "Page", "Offset"}
+	paginationSizeFields  = []string{"PageSize", "Limit", "Size"}
+	paginationTotalFields = []string{"Total", "TotalCount", "TotalItems"}
+)
+
+// paginationFields gets the PaginationFields for method by looking
+// for conventionally named fields in its input and output objects.
+func paginationFields(def parser.Definition, method parser.Method) (PaginationFields, error) {
+	var fields PaginationFields
+	input, err := def.Object(method.InputObject.CleanObjectName)
+	if err != nil {
+		return fields, errors.Wrapf(err, "pagination_fields: %s", method.InputObject.CleanObjectName)
+	}
+	output, err := def.Object(method.OutputObject.CleanObjectName)
+	if err != nil {
+		return fields, errors.Wrapf(err, "pagination_fields: %s", method.OutputObject.CleanObjectName)
+	}
+	fields.Page = findFieldJSONName(*input, paginationPageFields)
+	fields.Size = findFieldJSONName(*input, paginationSizeFields)
+	fields.Total = findFieldJSONName(*output, paginationTotalFields)
+	return fields, nil
+}
+
+// findFieldJSONName gets the JSON name of the first field in object
+// named one of names.
+func findFieldJSONName(object parser.Object, names []string) string {
+	for _, name := range names {
+		for _, field := range object.Fields {
+			if field.Name == name {
+				return field.NameLowerCamel
+			}
+		}
+	}
+	return ""
+}
+
+// tsPageInfo is the Relay PageInfo type shared by every connection.
+const tsPageInfo = `export interface PageInfo {
+	hasNextPage: boolean;
+	hasPreviousPage: boolean;
+	startCursor: string | null;
+	endCursor: string | null;
+}
+`
+
+// tsConnections gets Relay style connection types for the objects
+// listed by each paginated method, like GreetingConnection with its
+// edges and pageInfo, along with the PageInfo type they share.
+// A method is paginated if it has a page field, and the connection
+// wraps the first list of objects in its output.
+// Returns an empty string if there are no paginated methods.
+func tsConnections(def parser.Definition) (template.HTML, error) {
+	var buf strings.Builder
+	seen := make(map[string]bool)
+	for _, service := range def.Services {
+		for _, method := range service.Methods {
+			fields, err := paginationFields(def, method)
+			if err != nil {
+				return "", err
+			}
+			if fields.Page == "" {
+				continue
+			}
+			output, err := def.Object(method.OutputObject.CleanObjectName)
+			if err != nil {
+				return "", errors.Wrapf(err, "ts_connections: %s", method.OutputObject.CleanObjectName)
+			}
+			node := listedObjectName(*output)
+			if node == "" || seen[node] {
+				continue
+			}
+			seen[node] = true
+			fmt.Fprintf(&buf, "\nexport interface %sEdge {\n\tcursor: string;\n\tnode: %s;\n}\n", node, node)
+			fmt.Fprintf(&buf, "\nexport interface %sConnection {\n\tedges: %sEdge[];\n\tpageInfo: PageInfo;\n}\n", node, node)
+		}
+	}
+	if buf.Len() == 0 {
+		return "", nil
+	}
+	return template.HTML(tsPageInfo + buf.String()), nil
+}
+
+// listedObjectName gets the TypeScript name of the objects in the
+// first list of objects in object.
+// Returns an empty string if there is no such field.
+func listedObjectName(object parser.Object) string {
+	for _, field := range object.Fields {
+		if field.Type.Multiple && field.Type.IsObject {
+			return field.Type.TSType
+		}
+	}
+	return ""
+}
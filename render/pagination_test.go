@@ -0,0 +1,41 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/pacedotdev/oto/parser"
+)
+
+func TestRenderPaginationFields(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/sorting")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	is.Equal(len(def.Services), 1)
+	s, err := Render(`<%= for (service) in def.Services { %><%= for (method) in service.Methods { %><% let fields = pagination_fields(method) %><%= method.Name %>: page=<%= fields.Page %> size=<%= fields.Size %> total=<%= fields.Total %>
+<% } %><% } %>`, def, nil)
+	is.NoErr(err)
+	is.Equal(s, "Get: page= size= total=\nList: page=cursor size=limit total=total\n")
+}
+
+func TestTSConnections(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := p.Parse()
+	is.NoErr(err)
+	s, err := Render(`<%= ts_connections(def) %>`, def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "export interface PageInfo {\n\thasNextPage: boolean;"))
+	is.True(strings.Contains(s, "export interface GreetingEdge {\n\tcursor: string;\n\tnode: Greeting;\n}\n"))
+	is.True(strings.Contains(s, "export interface GreetingConnection {\n\tedges: GreetingEdge[];\n\tpageInfo: PageInfo;\n}\n"))
+	is.Equal(strings.Count(s, "Connection {"), 1) // only GetGreetings is paginated
+
+	s, err = Render(`<%= ts_connections(def) %>`, parser.Definition{}, nil)
+	is.NoErr(err)
+	is.Equal(s, "")
+}
@@ -3,8 +3,11 @@ package render
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"go/doc"
 	"html/template"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/structtag"
@@ -19,16 +22,59 @@ func Render(template string, def parser.Definition, params map[string]interface{
 	ctx.Set("camelize_down", camelizeDown)
 	ctx.Set("camelize_up", camelizeUp)
 	ctx.Set("camelize_up_field", camelizeUpField)
+	ctx.Set("snake_down", func(word string) string {
+		return snakeDown(word, def.Acronyms)
+	})
 	ctx.Set("def", def)
 	ctx.Set("params", params)
 	ctx.Set("json", toJSONHelper)
 	ctx.Set("json_inline", toJSONInlineHelper)
+	ctx.Set("object_example_comment", objectExampleComment)
 	ctx.Set("format_comment_line", formatCommentLine)
 	ctx.Set("format_comment_text", formatCommentText)
 	ctx.Set("format_comment_html", formatCommentHTML)
+	ctx.Set("format_jsdoc", formatJSDoc)
 	ctx.Set("format_tags", formatTags)
 	ctx.Set("object_golang", ObjectGolang)
 	ctx.Set("smart_prefix", smartPrefix)
+	ctx.Set("quote_join", quoteJoin)
+	ctx.Set("request_bodies", requestBodies)
+	ctx.Set("request_body_name", requestBodyName)
+	ctx.Set("excluded_in", excludedIn)
+	ctx.Set("vendor_extensions", vendorExtensions)
+	ctx.Set("ts_implements", tsImplements)
+	ctx.Set("ts_type_guard", tsTypeGuard)
+	ctx.Set("ts_enum", tsEnum)
+	ctx.Set("ts_connections", tsConnections)
+	ctx.Set("ts_type", tsType)
+	ctx.Set("go_type", goType)
+	ctx.Set("swift_type", swiftType)
+	ctx.Set("dart_type", dartType)
+	ctx.Set("python_type", pythonType)
+	ctx.Set("rust_type", rustType)
+	ctx.Set("kotlin_type", kotlinType)
+	ctx.Set("csharp_type", csharpType)
+	ctx.Set("swift_enum", swiftEnum)
+	ctx.Set("zod_schema_ref", zodSchemaRef)
+	ctx.Set("zod_transform", zodTransform)
+	ctx.Set("cache_control", cacheControl)
+	ctx.Set("base_object_name", parser.BaseObjectName)
+	ctx.Set("error_codes", func() []string {
+		return def.ErrorCodes()
+	})
+	// owner gets the Object a field belongs to. Plush cannot select
+	// from a call, so bind the result first:
+	//
+	//	<% let o = owner(field) %><%= o.Comment %>
+	ctx.Set("owner", func(field parser.Field) (*parser.Object, error) {
+		return def.Object(field.ObjectName)
+	})
+	ctx.Set("pagination_fields", func(method parser.Method) (PaginationFields, error) {
+		return paginationFields(def, method)
+	})
+	ctx.Set("operation_id", func(service parser.Service, method parser.Method) (string, error) {
+		return def.OperationID(service, method)
+	})
 	s, err := plush.Render(string(template), ctx)
 	if err != nil {
 		return "", err
@@ -36,6 +82,44 @@ func Render(template string, def parser.Definition, params map[string]interface{
 	return s, nil
 }
 
+// RenderWithDelims renders the template using the Definition, with
+// left and right used in place of the plush <% and %> delimiters.
+// This is useful when generating templates that themselves contain
+// plush-like syntax.
+// Any literal <% in the template is escaped so it appears in the output.
+func RenderWithDelims(template string, def parser.Definition, params map[string]interface{}, left, right string) (string, error) {
+	if left == "" || right == "" {
+		return "", errors.New("left and right delimiters must not be empty")
+	}
+	template = strings.ReplaceAll(template, "<%", `\<%`)
+	template = replaceDelims(template, left, right)
+	return Render(template, def, params)
+}
+
+// replaceDelims replaces each left...right pair in s with the plush
+// <% and %> delimiters. Unmatched left delimiters are left alone.
+func replaceDelims(s, left, right string) string {
+	var buf strings.Builder
+	for {
+		start := strings.Index(s, left)
+		if start == -1 {
+			break
+		}
+		end := strings.Index(s[start+len(left):], right)
+		if end == -1 {
+			break
+		}
+		end += start + len(left)
+		buf.WriteString(s[:start])
+		buf.WriteString("<%")
+		buf.WriteString(s[start+len(left) : end])
+		buf.WriteString("%>")
+		s = s[end+len(right):]
+	}
+	buf.WriteString(s)
+	return buf.String()
+}
+
 func toJSONHelper(v interface{}, prefix, indent string) (template.HTML, error) {
 	if indent == "" {
 		indent = "\t"
@@ -47,6 +131,25 @@ func toJSONHelper(v interface{}, prefix, indent string) (template.HTML, error) {
 	return template.HTML(b), nil
 }
 
+// objectExampleComment gets the example JSON for the object (see
+// parser.Definition.Example) as a block of // comments, for use as
+// a doc comment.
+func objectExampleComment(def parser.Definition, object parser.Object) (template.HTML, error) {
+	example, err := def.Example(object)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(example, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	for _, line := range strings.Split(string(b), "\n") {
+		buf.WriteString("//\t" + line + "\n")
+	}
+	return template.HTML(buf.String()), nil
+}
+
 func toJSONInlineHelper(v interface{}) (template.HTML, error) {
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -68,6 +171,55 @@ func formatCommentText(s string) template.HTML {
 	return template.HTML(buf.String())
 }
 
+// formatJSDoc formats the comment as a JSDoc block, with each line
+// prefixed by indent.
+// The deprecated, default and example metadata become @deprecated,
+// @default and @example tags. The deprecated_by metadata becomes
+// @deprecated Use replacement instead.
+// Returns an empty string if there is nothing to document.
+func formatJSDoc(comment string, metadata map[string]interface{}, indent string) (template.HTML, error) {
+	var lines []string
+	if comment != "" {
+		var buf bytes.Buffer
+		doc.ToText(&buf, comment, "", "\t", 80)
+		lines = strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	}
+	if deprecatedBy, _ := metadata["deprecated_by"].(string); deprecatedBy != "" {
+		lines = append(lines, "@deprecated Use "+deprecatedBy+" instead.")
+	} else {
+		switch deprecated := metadata["deprecated"].(type) {
+		case bool:
+			if deprecated {
+				lines = append(lines, "@deprecated")
+			}
+		case string:
+			lines = append(lines, "@deprecated "+deprecated)
+		}
+	}
+	for _, tag := range []string{"default", "example"} {
+		value, ok := metadata[tag]
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return "", errors.Wrap(err, tag)
+		}
+		lines = append(lines, "@"+tag+" "+string(b))
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	var buf strings.Builder
+	buf.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "*/", `*\/`)
+		buf.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	buf.WriteString(indent + " */\n")
+	return template.HTML(buf.String()), nil
+}
+
 func formatCommentHTML(s string) template.HTML {
 	var buf bytes.Buffer
 	doc.ToHTML(&buf, s, nil)
@@ -104,3 +256,243 @@ func smartPrefix(prefix, s string) string {
 	}
 	return prefix + s
 }
+
+// excludedIn gets whether the field should be left out of the
+// output for the target generator.
+func excludedIn(field parser.Field, target string) bool {
+	return field.IsExcludedIn(target)
+}
+
+// vendorExtension is a vendor extension key and value.
+type vendorExtension struct {
+	Key   string
+	Value interface{}
+}
+
+// vendorExtensions gets the vendor extensions in the metadata (see
+// parser.VendorExtensions), sorted by key so the output is stable.
+func vendorExtensions(metadata map[string]interface{}) []vendorExtension {
+	var extensions []vendorExtension
+	for key, value := range parser.VendorExtensions(metadata) {
+		extensions = append(extensions, vendorExtension{Key: key, Value: value})
+	}
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Key < extensions[j].Key
+	})
+	return extensions
+}
+
+// zodSchemaRef gets the name used to refer to the Zod schema for
+// an object, like greetingSchema.
+// When schemas are split across files, namespaces maps object names
+// to the namespace (or import alias) their schema is in, and the
+// reference becomes Schemas.greetingSchema. Passing the template
+// params allows the namespaces to be set with -params.
+func zodSchemaRef(objectName string, namespaces map[string]interface{}) string {
+	ref := camelizeDown(objectName) + "Schema"
+	if namespace, ok := namespaces[objectName]; ok && namespace != "" {
+		return fmt.Sprintf("%v.%s", namespace, ref)
+	}
+	return ref
+}
+
+// zodTransforms are the Zod transforms for the known values of the
+// transform field metadata.
+var zodTransforms = map[string]string{
+	"trim":      ".transform((s) => s.trim())",
+	"lowercase": ".transform((s) => s.toLowerCase())",
+	"uppercase": ".transform((s) => s.toUpperCase())",
+}
+
+// zodTransform gets the Zod transform for string fields with
+// transform metadata, like .transform((s) => s.trim()).
+// Returns an empty string for other fields, or unknown transforms.
+func zodTransform(field parser.Field) template.HTML {
+	if field.Type.JSType != "string" || field.Type.Multiple {
+		return ""
+	}
+	return template.HTML(zodTransforms[field.Transform])
+}
+
+// cacheControl gets the Cache-Control header value for responses
+// from the method, like max-age=60, or an empty string if the
+// method has no CacheTTL.
+func cacheControl(method parser.Method) string {
+	if method.CacheTTL <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("max-age=%d", int(method.CacheTTL.Seconds()))
+}
+
+// quoteJoin quotes each item as a string literal and joins them
+// with sep. quote_join(["a","b"], ", ") produces "a", "b".
+// items may be a []string or a []interface{} (as produced by
+// array literals in templates).
+func quoteJoin(items interface{}, sep string) (template.HTML, error) {
+	var strs []string
+	switch items := items.(type) {
+	case []string:
+		strs = items
+	case []interface{}:
+		strs = make([]string, len(items))
+		for i := range items {
+			strs[i] = fmt.Sprintf("%v", items[i])
+		}
+	default:
+		return "", errors.Errorf("quote_join: unsupported type %T", items)
+	}
+	quoted := make([]string, len(strs))
+	for i := range strs {
+		quoted[i] = strconv.Quote(strs[i])
+	}
+	return template.HTML(strings.Join(quoted, sep)), nil
+}
+
+// tsImplements gets an implements clause for objects that describe a
+// subset of another object with pick or omit metadata, along with
+// from to name the base object.
+// An object with from: "User" and pick: ["id"] produces
+// implements Pick<User, "id">. Objects without pick or omit
+// produce an empty string.
+func tsImplements(object parser.Object) (template.HTML, error) {
+	var utility string
+	var keys interface{}
+	if pick, ok := object.Metadata["pick"]; ok {
+		utility, keys = "Pick", pick
+	}
+	if omit, ok := object.Metadata["omit"]; ok {
+		if utility != "" {
+			return "", errors.Errorf("%s: pick and omit cannot be used together", object.Name)
+		}
+		utility, keys = "Omit", omit
+	}
+	if utility == "" {
+		return "", nil
+	}
+	from, ok := object.Metadata["from"].(string)
+	if !ok || from == "" {
+		return "", errors.Errorf("%s: %s requires from metadata naming the base object", object.Name, strings.ToLower(utility))
+	}
+	quoted, err := quoteJoin(keys, " | ")
+	if err != nil {
+		return "", errors.Wrapf(err, "%s: %s", object.Name, strings.ToLower(utility))
+	}
+	return template.HTML(fmt.Sprintf(" implements %s<%s, %s>", utility, from, quoted)), nil
+}
+
+// tsTypeGuard gets a TypeScript type guard function for the object,
+// like isGreeting(x: any): x is Greeting, which checks that the
+// required fields are present and have the right basic types.
+// Optional, omitempty and excluded fields are not checked.
+func tsTypeGuard(object parser.Object) template.HTML {
+	checks := []string{"x != null", "typeof x === 'object'"}
+	for _, field := range object.Fields {
+		if field.IsExcludedIn("typescript") || field.OutputOnly {
+			continue
+		}
+		if field.Optional || field.OmitEmpty || field.Type.IsOptional() {
+			continue
+		}
+		name := "x." + field.NameLowerCamel
+		switch {
+		case field.Type.Multiple:
+			// nil slices are encoded as null
+			checks = append(checks, fmt.Sprintf("(%s == null || Array.isArray(%s))", name, name))
+		case field.Type.IsMap():
+			checks = append(checks, fmt.Sprintf("(%s == null || typeof %s === 'object')", name, name))
+		case field.Type.IsObject:
+			checks = append(checks, fmt.Sprintf("typeof %s === 'object'", name))
+		case field.Type.JSType == "any":
+			checks = append(checks, fmt.Sprintf("%s !== undefined", name))
+		default:
+			checks = append(checks, fmt.Sprintf("typeof %s === '%s'", name, field.Type.JSType))
+		}
+	}
+	return template.HTML(fmt.Sprintf("export function is%s(x: any): x is %s {\n\treturn %s\n}\n",
+		object.Name, object.Name, strings.Join(checks, "\n\t\t&& ")))
+}
+
+// swiftKeywords are the Swift keywords that need backticks when they
+// are used as enum case names.
+var swiftKeywords = []string{
+	"as", "break", "case", "catch", "class", "continue", "default",
+	"defer", "do", "else", "enum", "extension", "fallthrough", "false",
+	"for", "func", "guard", "if", "import", "in", "init", "is", "let",
+	"nil", "operator", "private", "protocol", "public", "repeat",
+	"return", "self", "static", "struct", "super", "switch", "throw",
+	"true", "try", "var", "where", "while",
+}
+
+// swiftEnum gets a Swift enum for the enum, with a case for each of
+// its values, like:
+//
+//	enum Status: String, Codable {
+//		case active = "active"
+//	}
+//
+// String enums have String raw values, and integer enums have Int
+// raw values.
+func swiftEnum(enum parser.Enum) template.HTML {
+	rawType := "Int"
+	if enum.BaseType == "string" {
+		rawType = "String"
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "enum %s: %s, Codable {\n", enum.Name, rawType)
+	for _, value := range enum.Values {
+		if value.Comment != "" {
+			doc.ToText(&buf, value.Comment, "\t// ", "", 80)
+		}
+		name := camelizeDown(value.ShortName)
+		for _, keyword := range swiftKeywords {
+			if name == keyword {
+				name = "`" + name + "`"
+				break
+			}
+		}
+		literal := fmt.Sprint(value.Value)
+		if s, ok := value.Value.(string); ok {
+			literal = strconv.Quote(s)
+		}
+		fmt.Fprintf(&buf, "\tcase %s = %s\n", name, literal)
+	}
+	buf.WriteString("}\n")
+	return template.HTML(buf.String())
+}
+
+// tsEnum gets a TypeScript union type for the enum, like
+// export type Status = "active" | "archived".
+// If any of the values have comments, it also gets a StatusLabels
+// map from each value to its comment, for use as labels in UIs.
+// Values without comments are labelled with their ShortName.
+func tsEnum(enum parser.Enum) template.HTML {
+	var buf bytes.Buffer
+	literals := make([]string, len(enum.Values))
+	hasComments := false
+	for i, value := range enum.Values {
+		literals[i] = fmt.Sprint(value.Value)
+		if s, ok := value.Value.(string); ok {
+			literals[i] = strconv.Quote(s)
+		}
+		if value.Comment != "" {
+			hasComments = true
+		}
+	}
+	if len(literals) == 0 {
+		literals = []string{"never"}
+	}
+	fmt.Fprintf(&buf, "export type %s = %s\n", enum.Name, strings.Join(literals, " | "))
+	if !hasComments {
+		return template.HTML(buf.String())
+	}
+	fmt.Fprintf(&buf, "\nexport const %sLabels: Record<%s, string> = {\n", enum.Name, enum.Name)
+	for i, value := range enum.Values {
+		label := strings.Join(strings.Fields(value.Comment), " ")
+		if label == "" {
+			label = value.ShortName
+		}
+		fmt.Fprintf(&buf, "\t%s: %s,\n", literals[i], strconv.Quote(label))
+	}
+	buf.WriteString("}\n")
+	return template.HTML(buf.String())
+}
@@ -1,7 +1,9 @@
 package render
 
 import (
+	"html/template"
 	"log"
+	"os"
 	"strings"
 	"testing"
 
@@ -32,6 +34,31 @@ package <%= def.PackageName %>`
 	}
 }
 
+func TestRenderWithDelims(t *testing.T) {
+	is := is.New(t)
+	def := parser.Definition{
+		PackageName: "services",
+	}
+	params := map[string]interface{}{
+		"Description": "Package services contains services.",
+	}
+	template := `// {{= params["Description"] }}
+package {{= def.PackageName }}
+// <%= literal %>`
+	s, err := RenderWithDelims(template, def, params, "{{", "}}")
+	is.NoErr(err)
+	for _, should := range []string{
+		"// Package services contains services.",
+		"package services",
+		"// <%= literal %>",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
 // TestRenderCommentsWithQuotes addresses https://github.com/pacedotdev/oto/issues/17.
 func TestRenderCommentsWithQuotes(t *testing.T) {
 	is := is.New(t)
@@ -62,6 +89,332 @@ func TestRenderCommentsWithQuotes(t *testing.T) {
 	}
 }
 
+func TestRenderOpenAPIContentTypes(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/contenttypes")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"\"/ReportService.ExportReport\":",
+		"            text/csv:\n              schema:\n                $ref: \"#/components/schemas/ExportReportResponse\"",
+		"    UploadReportRequest:\n      required: true\n      content:\n        multipart/form-data:\n          schema:\n            $ref: \"#/components/schemas/UploadReportRequest\"",
+		"    ExportReportRequest:\n      required: true\n      content:\n        application/json:\n          schema:\n            $ref: \"#/components/schemas/ExportReportRequest\"",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderOpenAPIBinary(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/binary")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"            application/octet-stream:\n              schema:\n                type: string\n                format: binary\n",
+		"    UploadRequest:\n      required: true\n      content:\n        application/octet-stream:\n          schema:\n            type: string\n            format: binary\n",
+		"    DownloadRequest:\n      required: true\n      content:\n        application/json:\n          schema:\n            $ref: \"#/components/schemas/DownloadRequest\"",
+		"            application/json:\n              schema:\n                $ref: \"#/components/schemas/UploadResponse\"",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderOpenAPIOperationIDs(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/operationids")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"  \"/GreeterService.Greet\":\n    post:\n      operationId: greeterServiceGreet\n",
+		"  \"/GreeterService.Farewell\":\n    post:\n      operationId: sayGoodbye\n",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderCacheTTL(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/cachettl")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	openapi, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(openapi), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "          headers:\n            Cache-Control:\n"))
+	is.True(strings.Contains(s, "                example: \"max-age=60\"\n"))
+	is.Equal(strings.Count(s, "Cache-Control:"), 1) // only Get is cached
+	server, err := os.ReadFile("../otohttp/templates/server.go.plush")
+	is.NoErr(err)
+	s, err = Render(string(server), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "\tw.Header().Set(\"Cache-Control\", \"max-age=60\")\n\tif err := otohttp.Encode("))
+	is.Equal(strings.Count(s, "Cache-Control"), 1)
+}
+
+func TestRenderOpenAPIVendorExtensions(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/vendorextensions")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"      x-internal-id: 42\n      x-rate-limit: {\"perMinute\":10}\n",
+		"    PlaceOrderRequest:\n      type: object\n      x-entity: \"order\"\n",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderOpenAPIUnknownKeys(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/unknownkeys")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "    SaveRequest:\n      type: object\n      additionalProperties: false\n"))
+	is.True(strings.Contains(s, "    Settings:\n      type: object\n      properties:"))
+}
+
+func TestVendorExtensions(t *testing.T) {
+	is := is.New(t)
+	extensions := vendorExtensions(map[string]interface{}{
+		"x-rate-limit": 10,
+		"x-featured":   true,
+		"featured":     true,
+		"x-internal":   "yes",
+	})
+	is.Equal(len(extensions), 3)
+	// sorted by key
+	is.Equal(extensions[0].Key, "x-featured")
+	is.Equal(extensions[0].Value, true)
+	is.Equal(extensions[1].Key, "x-internal")
+	is.Equal(extensions[1].Value, "yes")
+	is.Equal(extensions[2].Key, "x-rate-limit")
+	is.Equal(extensions[2].Value, 10)
+	is.Equal(len(vendorExtensions(nil)), 0)
+}
+
+func TestRenderTypeScriptExcludeIn(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/exclusions")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "this.name = data.name;"))
+	is.True(!strings.Contains(s, "serverNotes")) // excluded in typescript
+	is.True(!strings.Contains(s, "internal"))    // excluded everywhere
+}
+
+func TestRenderExcludeIn(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/exclusions")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	getAccountResponse, err := def.Object("GetAccountResponse")
+	is.NoErr(err)
+	serverNotes := &getAccountResponse.Fields[1]
+	for _, tc := range []struct {
+		target   string
+		template string
+		field    string
+	}{
+		{"swift", "client.swift.plush", "serverNotes"},
+		{"dart", "x/client.dart.plush", "serverNotes"},
+		{"go", "client.go.plush", "ServerNotes"},
+		{"openapi", "openapi.yaml.plush", "serverNotes"},
+	} {
+		template, err := os.ReadFile("../otohttp/templates/" + tc.template)
+		is.NoErr(err)
+		serverNotes.ExcludeIn = []string{"graphql"}
+		s, err := Render(string(template), def, nil)
+		is.NoErr(err)
+		is.True(strings.Contains(s, tc.field))                     // only excluded in graphql
+		is.True(!strings.Contains(strings.ToLower(s), "internal")) // excluded everywhere
+		serverNotes.ExcludeIn = []string{tc.target}
+		s, err = Render(string(template), def, nil)
+		is.NoErr(err)
+		is.True(!strings.Contains(s, tc.field)) // excluded in the target
+	}
+}
+
+func TestRenderTypeScriptResultTypes(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "export type ApiResult<T> = { error: null; data: T } | { error: string; data: null }\n"))
+	is.True(strings.Contains(s, "// GreeterServiceGreetResult is the result of calling GreeterService.Greet.\nexport type GreeterServiceGreetResult = ApiResult<GreetResponse>\n"))
+}
+
+func TestRenderTypeScriptJSDoc(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"/**\n * Greeting contains the pleasentry.\n */\nexport class Greeting {",
+		"\t/**\n\t * Text is the message.\n\t * @example \"Hello there\"\n\t */\n\ttext: string",
+		"\t/**\n\t * Greet creates a Greeting for one or more people.\n\t */\n\tasync greet(",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestFormatJSDoc(t *testing.T) {
+	is := is.New(t)
+	s, err := formatJSDoc("", nil, "")
+	is.NoErr(err)
+	is.Equal(s, template.HTML(""))
+	s, err = formatJSDoc("Limit is the */ maximum.", map[string]interface{}{
+		"deprecated": "Use PageSize instead.",
+		"default":    float64(10),
+		"example":    float64(25),
+	}, "\t")
+	is.NoErr(err)
+	is.Equal(s, template.HTML("\t/**\n\t * Limit is the *\\/ maximum.\n\t * @deprecated Use PageSize instead.\n\t * @default 10\n\t * @example 25\n\t */\n"))
+	s, err = formatJSDoc("", map[string]interface{}{"deprecated": true}, "")
+	is.NoErr(err)
+	is.Equal(s, template.HTML("/**\n * @deprecated\n */\n"))
+	s, err = formatJSDoc("", map[string]interface{}{"deprecated": true, "deprecated_by": "pageSize"}, "")
+	is.NoErr(err)
+	is.Equal(s, template.HTML("/**\n * @deprecated Use pageSize instead.\n */\n"))
+}
+
+func TestRenderTypeScriptDeprecatedBy(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/deprecatedby")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	listRequest, err := def.Object("ListRequest")
+	is.NoErr(err)
+	is.Equal(listRequest.Fields[0].DeprecatedBy, "pageSize")
+	is.Equal(listRequest.Fields[1].DeprecatedBy, "")
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, "\t * Limit is the maximum number of items.\n\t * @deprecated Use pageSize instead.\n\t */\n\tlimit: number"))
+}
+
+func TestRenderTypeScriptUtilityTypes(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/utilitytypes")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	is.True(strings.Contains(s, `export class RenameRequest implements Pick<User, "id" | "name"> {`))
+	is.True(strings.Contains(s, `export class LookupRequest implements Omit<User, "id"> {`))
+	is.True(strings.Contains(s, `export class User {`))
+}
+
+func TestTSImplements(t *testing.T) {
+	is := is.New(t)
+	s, err := tsImplements(parser.Object{Name: "Plain"})
+	is.NoErr(err)
+	is.Equal(s, template.HTML(""))
+	_, err = tsImplements(parser.Object{
+		Name:     "MissingFrom",
+		Metadata: map[string]interface{}{"pick": []interface{}{"id"}},
+	})
+	is.True(err != nil) // from is required
+	_, err = tsImplements(parser.Object{
+		Name: "Both",
+		Metadata: map[string]interface{}{
+			"from": "User",
+			"pick": []interface{}{"id"},
+			"omit": []interface{}{"name"},
+		},
+	})
+	is.True(err != nil) // pick and omit are mutually exclusive
+}
+
+func TestRenderOwner(t *testing.T) {
+	is := is.New(t)
+	def := parser.Definition{
+		Objects: []parser.Object{
+			{
+				Name:    "Greeting",
+				Comment: "Greeting is a friendly message.",
+				Fields: []parser.Field{
+					{Name: "Text", ObjectName: "Greeting"},
+				},
+			},
+		},
+	}
+	s, err := Render(`<%= for (object) in def.Objects { %><%= for (field) in object.Fields { %><% let o = owner(field) %><%= field.Name %>: <%= o.Comment %><% } %><% } %>`, def, nil)
+	is.NoErr(err)
+	is.Equal(s, "Text: Greeting is a friendly message.")
+}
+
+func TestRenderBaseObjectName(t *testing.T) {
+	is := is.New(t)
+	s, err := Render(`<%= base_object_name("GreetRequest") %> <%= base_object_name("GreetResponse") %> <%= base_object_name("Greeting") %>`, parser.Definition{}, nil)
+	is.NoErr(err)
+	is.Equal(s, "Greet Greet Greeting")
+}
+
 func TestCamelizeDown(t *testing.T) {
 	for in, expected := range map[string]string{
 		"CamelsAreGreat": "camelsAreGreat",
@@ -123,3 +476,383 @@ func TestSmartPrefix(t *testing.T) {
 	is.Equal(actual, "publicObject")
 
 }
+
+func TestQuoteJoin(t *testing.T) {
+	is := is.New(t)
+
+	actual, err := quoteJoin([]string{"a", "b"}, ", ")
+	is.NoErr(err)
+	is.Equal(string(actual), `"a", "b"`)
+
+	actual, err = quoteJoin([]string{`say "hi"`}, ", ")
+	is.NoErr(err)
+	is.Equal(string(actual), `"say \"hi\""`)
+
+	s, err := Render(`<%= quote_join(["a","b"], ", ") %>`, parser.Definition{}, nil)
+	is.NoErr(err)
+	is.Equal(s, `"a", "b"`)
+}
+
+func TestRenderTypeScriptRequestOptions(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"export interface RequestOptions {\n\t// signal aborts the request when it is aborted.\n\tsignal?: AbortSignal;",
+		"\ttimeoutMs?: number;\n}",
+		"async greet(greetRequest?: GreetRequest, modifyHeaders?: HeadersFunc, options?: RequestOptions): Promise<GreetResponse> {",
+		"timeout = setTimeout(() => controller.abort(), options.timeoutMs);",
+		"if (signal && signal.aborted) {\n\t\t\t\tcontroller.abort();",
+		"signal.addEventListener('abort', abort);",
+		"options.signal.removeEventListener('abort', abort);",
+		"body: JSON.stringify(greetRequest),\n\t\t\t\tsignal: signal,\n\t\t\t})",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderTypeScriptBinary(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/binary")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"async download(downloadRequest?: DownloadRequest, modifyHeaders?: HeadersFunc, options?: RequestOptions): Promise<Blob> {",
+		"headers.set('Accept', 'application/octet-stream');",
+		"return await response.blob()",
+		"export type FileServiceDownloadResult = ApiResult<Blob>",
+		"async upload(uploadRequest: Blob, modifyHeaders?: HeadersFunc, options?: RequestOptions): Promise<UploadResponse> {",
+		"headers.set('Content-Type', 'application/octet-stream');",
+		"body: uploadRequest,",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+	is.True(!strings.Contains(s, "uploadRequest = new UploadRequest()")) // Blob is required
+}
+
+func TestZodSchemaRef(t *testing.T) {
+	is := is.New(t)
+	is.Equal(zodSchemaRef("Greeting", nil), "greetingSchema")
+	namespaces := map[string]interface{}{
+		"Greeting": "Schemas",
+	}
+	is.Equal(zodSchemaRef("Greeting", namespaces), "Schemas.greetingSchema")
+	is.Equal(zodSchemaRef("GreetRequest", namespaces), "greetRequestSchema")
+	s, err := Render(`<%= zod_schema_ref("Greeting", params) %>`, parser.Definition{}, namespaces)
+	is.NoErr(err)
+	is.Equal(s, "Schemas.greetingSchema")
+}
+
+func TestZodTransform(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/transforms")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	signUpRequest, err := def.Object("SignUpRequest")
+	is.NoErr(err)
+	is.Equal(signUpRequest.Fields[0].Transform, "trim")
+	s, err := Render(`<%= for (object) in def.Objects { %><%= if (object.Name == "SignUpRequest") { %><%= for (field) in object.Fields { %><%= field.NameLowerCamel %>: z.string()<%= zod_transform(field) %>
+<% } %><% } %><% } %>`, def, nil)
+	is.NoErr(err)
+	is.Equal(s, `name: z.string().transform((s) => s.trim())
+email: z.string().transform((s) => s.toLowerCase())
+code: z.string().transform((s) => s.toUpperCase())
+nickname: z.string()
+bio: z.string()
+`)
+}
+
+func TestRenderSwiftOptional(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/swiftoptional")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.swift.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"\tvar name: String?\n",
+		"\tvar nicknames: Optional<[String]>\n",
+		"\tvar profile: Profile?\n",
+		"\tvar profile: Optional<Profile>\n",
+		"\tvar version: Int?\n",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderTypeScriptPointerSlices(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/pointerslices")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"\tgreetings?: (Greeting | null)[];\n",
+		"this.greetings.push(data.greetings[i] == null ? null : new Greeting(data.greetings[i]));",
+		"\tnames?: (string | null)[];\n",
+		"\tlanguages?: string[];\n",
+		"\tfeatured?: Greeting;\n",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderTypeScriptTypeGuards(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	should := `export function isGreeting(x: any): x is Greeting {
+	return x != null
+		&& typeof x === 'object'
+		&& typeof x.text === 'string'
+}`
+	if !strings.Contains(s, should) {
+		t.Errorf("missing: %s", should)
+		is.Fail()
+	}
+	// the injected error field is output only, so is not required
+	is.True(!strings.Contains(s, "typeof x.error"))
+}
+
+func TestRenderSwiftEnums(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/enums")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.swift.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		`// Status is the state of an item.
+enum Status: String, Codable {
+	// StatusActive is for items in use.
+	case active = "active"
+	// StatusArchived is for items that are kept but not in use.
+	case archived = "archived"
+}
+`,
+		`enum Priority: Int, Codable {
+	// PriorityLow can wait.
+	case low = 1
+	// PriorityHigh is urgent.
+	case high = 2
+}
+`,
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestSwiftEnumKeywords(t *testing.T) {
+	is := is.New(t)
+	s := swiftEnum(parser.Enum{
+		Name:     "Mode",
+		BaseType: "string",
+		Values: []parser.EnumValue{
+			{Name: "ModeDefault", ShortName: "Default", Value: "default"},
+		},
+	})
+	is.Equal(string(s), "enum Mode: String, Codable {\n\tcase `default` = \"default\"\n}\n")
+}
+
+func TestRenderTypeScriptEnumLabels(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/enums")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		`/**
+ * Status is the state of an item.
+ */
+export type Status = "active" | "archived"
+
+export const StatusLabels: Record<Status, string> = {
+	"active": "StatusActive is for items in use.",
+	"archived": "StatusArchived is for items that are kept but not in use.",
+}
+`,
+		`export const PriorityLabels: Record<Priority, string> = {
+	1: "PriorityLow can wait.",
+	2: "PriorityHigh is urgent.",
+}
+`,
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestTSEnumWithoutComments(t *testing.T) {
+	is := is.New(t)
+	s := tsEnum(parser.Enum{
+		Name:     "Size",
+		BaseType: "string",
+		Values: []parser.EnumValue{
+			{Name: "SizeSmall", ShortName: "Small", Value: "small"},
+			{Name: "SizeLarge", ShortName: "Large", Value: "large"},
+		},
+	})
+	is.Equal(string(s), "export type Size = \"small\" | \"large\"\n")
+}
+
+func TestObjectExampleComment(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	def, err := p.Parse()
+	is.NoErr(err)
+	s, err := Render(`<% let object = def.Objects[6] %><%= object.Name %>
+<%= object_example_comment(def, object) %>`, def, nil)
+	is.NoErr(err)
+	is.Equal(s, `GreetResponse
+//	{
+//		"error": "something went wrong",
+//		"greeting": {
+//			"text": "Hello there"
+//		}
+//	}
+`)
+}
+
+func TestRenderImmutable(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/immutable")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	money, err := def.Object("Money")
+	is.NoErr(err)
+	is.True(money.Immutable)
+
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"\treadonly currency: string = stringDefault;\n",
+		"\treadonly units: number = numberDefault;\n",
+		"\tamount?: Money;\n", // ChargeRequest is not immutable
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+
+	template, err = os.ReadFile("../otohttp/templates/client.swift.plush")
+	is.NoErr(err)
+	s, err = Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"\tlet currency: String?\n",
+		"\tlet units: Int?\n",
+		"\tvar amount: Money?\n",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+}
+
+func TestRenderTypeScriptErrorCodes(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/errorcodes")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/client.ts.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	should := `export type ErrorCode = "CONFLICT" | "FORBIDDEN" | "NOT_FOUND"` + "\n"
+	if !strings.Contains(s, should) {
+		t.Errorf("missing: %s", should)
+		is.Fail()
+	}
+
+	p = parser.New("../parser/testdata/services/pleasantries")
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	def, err = p.Parse()
+	is.NoErr(err)
+	s, err = Render(string(template), def, nil)
+	is.NoErr(err)
+	is.True(!strings.Contains(s, "ErrorCode")) // no error codes
+}
+
+func TestRenderDartValuePointers(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/valuepointers")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/x/client.dart.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	for _, should := range []string{
+		"\tfinal int? limit;\n",
+		"\tfinal bool? enabled;\n",
+		"\tfinal List<double?>? weights;\n",
+		"\tfinal String? label;\n",
+		"\tfinal Owner? owner;\n",
+		"\tfinal int? version;\n",
+	} {
+		if !strings.Contains(s, should) {
+			t.Errorf("missing: %s", should)
+			is.Fail()
+		}
+	}
+	is.True(!strings.Contains(s, "??")) // pointers are only nullable once
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"strconv"
+
+	"github.com/pacedotdev/oto/parser"
+	"github.com/pkg/errors"
+)
+
+// requestBody is a request body shared by one or more methods.
+type requestBody struct {
+	// Name is the unique name of this request body.
+	Name string
+	// ContentType is the content type of the body.
+	ContentType string
+	// ObjectName is the clean name of the input object.
+	ObjectName string
+	// Binary is true if the body is raw binary data rather than
+	// the encoded input object.
+	Binary bool
+}
+
+// requestBodies gets the distinct request bodies taken by the methods
+// in def, so they can be described once and referenced by name.
+// Methods with the same input object, content type and
+// BinaryRequest setting share a body.
+// Bodies are named after their input object, with a numeric suffix if
+// the same object is sent with more than one content type.
+func requestBodies(def parser.Definition) []requestBody {
+	var bodies []requestBody
+	names := make(map[string]bool)
+	for _, service := range def.Services {
+		for _, method := range service.Methods {
+			if findRequestBody(bodies, method) != nil {
+				continue
+			}
+			name := method.InputObject.CleanObjectName
+			for i := 2; names[name]; i++ {
+				name = method.InputObject.CleanObjectName + strconv.Itoa(i)
+			}
+			names[name] = true
+			bodies = append(bodies, requestBody{
+				Name:        name,
+				ContentType: method.RequestContentType,
+				ObjectName:  method.InputObject.CleanObjectName,
+				Binary:      method.BinaryRequest,
+			})
+		}
+	}
+	return bodies
+}
+
+// requestBodyName gets the name of the request body (from
+// requestBodies) taken by method.
+func requestBodyName(def parser.Definition, method parser.Method) (string, error) {
+	body := findRequestBody(requestBodies(def), method)
+	if body == nil {
+		return "", errors.Errorf("request_body_name: no request body for %s", method.Name)
+	}
+	return body.Name, nil
+}
+
+func findRequestBody(bodies []requestBody, method parser.Method) *requestBody {
+	for i := range bodies {
+		if bodies[i].ObjectName == method.InputObject.CleanObjectName && bodies[i].ContentType == method.RequestContentType && bodies[i].Binary == method.BinaryRequest {
+			return &bodies[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package render
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/pacedotdev/oto/parser"
+)
+
+func TestRenderOpenAPIRequestBodies(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/requestbodies")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	template, err := os.ReadFile("../otohttp/templates/openapi.yaml.plush")
+	is.NoErr(err)
+	s, err := Render(string(template), def, nil)
+	is.NoErr(err)
+	is.Equal(strings.Count(s, "    PageRequest:\n      required: true"), 1)           // body defined once
+	is.Equal(strings.Count(s, "$ref: \"#/components/requestBodies/PageRequest\""), 2) // and referenced by both list methods
+	is.Equal(strings.Count(s, "    PageRequest2:\n      required: true\n      content:\n        text/csv:"), 1)
+	is.Equal(strings.Count(s, "$ref: \"#/components/requestBodies/PageRequest2\""), 1)
+}
@@ -153,6 +153,56 @@ func camelizeUp(word string) string {
 	return strings.ToUpper(word[:1]) + word[1:]
 }
 
+// snakeDown converts a name into lower snake case, keeping acronyms
+// together. "PreviewHTML" becomes "preview_html" and "HTTPError"
+// becomes "http_error".
+// The extra acronyms are kept together too, so with OAuth,
+// "OAuthToken" becomes "oauth_token" instead of "o_auth_token".
+func snakeDown(word string, acronyms []string) string {
+	var words []string
+	var rest string
+	for word != "" {
+		if ac := acronymPrefix(word, acronyms); ac != "" {
+			words = append(words, Split(rest)...)
+			words = append(words, ac)
+			rest = ""
+			word = word[len(ac):]
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(word)
+		rest += word[:size]
+		word = word[size:]
+	}
+	words = append(words, Split(rest)...)
+	var out []string
+	for _, w := range words {
+		if strings.IndexFunc(w, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) == -1 {
+			// spaces, underscores and other separators
+			continue
+		}
+		out = append(out, strings.ToLower(w))
+	}
+	return strings.Join(out, "_")
+}
+
+// acronymPrefix gets the acronym that word starts with, as long as
+// it is not followed by a lower case letter.
+// Returns an empty string if word doesn't start with any of them.
+func acronymPrefix(word string, acronyms []string) string {
+	for _, ac := range acronyms {
+		if ac == "" || !strings.HasPrefix(word, ac) {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(word[len(ac):])
+		if !unicode.IsLower(next) {
+			return ac
+		}
+	}
+	return ""
+}
+
 func isAcronym(word string) bool {
 	for _, ac := range baseAcronyms {
 		if strings.EqualFold(ac, word) {
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/matryer/is"
+	"github.com/pacedotdev/oto/parser"
 )
 
 func ExampleSplit() {
@@ -63,3 +64,31 @@ func TestCamelizeUpField(t *testing.T) {
 	actual := camelizeUpField("string[apiKey:bits]")
 	is.Equal(actual, "StringAPIKeyBits")
 }
+
+func TestSnakeDown(t *testing.T) {
+	is := is.New(t)
+	is.Equal(snakeDown("PreviewHTML", nil), "preview_html")
+	is.Equal(snakeDown("HTTPError", nil), "http_error")
+	is.Equal(snakeDown("HTML", nil), "html")
+	is.Equal(snakeDown("userID", nil), "user_id")
+	is.Equal(snakeDown("SimpleXMLParser", nil), "simple_xml_parser")
+	is.Equal(snakeDown("GL11Version", nil), "gl_11_version")
+	is.Equal(snakeDown("already_snake", nil), "already_snake")
+	is.Equal(snakeDown("OAuthToken", nil), "o_auth_token")
+	is.Equal(snakeDown("OAuthToken", []string{"OAuth"}), "oauth_token")
+	is.Equal(snakeDown("NewGraphQLAPI", []string{"OAuth", "GraphQL"}), "new_graphql_api")
+}
+
+func TestRenderSnakeDown(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/services/pleasantries")
+	p.Verbose = testing.Verbose()
+	p.ExcludeInterfaces = []string{"Ignorer"}
+	p.Acronyms = []string{"OAuth"}
+	def, err := p.Parse()
+	is.NoErr(err)
+	is.Equal(def.Acronyms, []string{"OAuth"})
+	s, err := Render(`<%= snake_down("PreviewHTML") %> <%= snake_down("OAuthToken") %>`, def, nil)
+	is.NoErr(err)
+	is.Equal(s, "preview_html oauth_token")
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"html/template"
+
+	"github.com/pacedotdev/oto/parser"
+)
+
+// tsType gets the complete TypeScript type for a field of type ftype,
+// like (Greeting | null)[] for []*Greeting or Record<string, number>
+// for map[string]int.
+// Pointers may be null.
+func tsType(ftype parser.FieldType) template.HTML {
+	typ := ftype.TSType
+	if ftype.Multiple {
+		if ftype.ElementIsPointer {
+			return template.HTML("(" + typ + " | null)[]")
+		}
+		return template.HTML(typ + "[]")
+	}
+	if ftype.IsOptional() {
+		return template.HTML(typ + " | null")
+	}
+	return template.HTML(typ)
+}
+
+// goType gets the complete Go type for a field of type ftype, like
+// []*Greeting. See parser.FieldType.String.
+func goType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.String())
+}
+
+// swiftType gets the complete Swift type for a field of type ftype.
+func swiftType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.SwiftTypeFull)
+}
+
+// dartType gets the complete Dart type for a field of type ftype.
+func dartType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.DartType)
+}
+
+// pythonType gets the complete Python type hint for a field of type
+// ftype.
+func pythonType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.PythonType)
+}
+
+// rustType gets the complete Rust type for a field of type ftype.
+func rustType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.RustType)
+}
+
+// kotlinType gets the complete Kotlin type for a field of type ftype.
+func kotlinType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.KotlinType)
+}
+
+// csharpType gets the complete C# type for a field of type ftype.
+func csharpType(ftype parser.FieldType) template.HTML {
+	return template.HTML(ftype.CSharpType)
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/pacedotdev/oto/parser"
+)
+
+func TestRenderTypeHelpers(t *testing.T) {
+	is := is.New(t)
+	p := parser.New("../parser/testdata/pointerslices")
+	p.Verbose = testing.Verbose()
+	def, err := p.Parse()
+	is.NoErr(err)
+	object, err := def.Object("GetGreetingsResponse")
+	is.NoErr(err)
+	is.Equal(object.Fields[0].Type.TypeName, "*Greeting") // []*Greeting
+	params := map[string]interface{}{"ftype": object.Fields[0].Type}
+	s, err := Render(`<% let ftype = params["ftype"] %><%= ts_type(ftype) %> <%= go_type(ftype) %> <%= swift_type(ftype) %> <%= kotlin_type(ftype) %>`, def, params)
+	is.NoErr(err)
+	is.Equal(s, "(Greeting | null)[] []*Greeting [Greeting]? List<Greeting?>")
+
+	p = parser.New("../parser/testdata/maps")
+	p.Verbose = testing.Verbose()
+	def, err = p.Parse()
+	is.NoErr(err)
+	object, err = def.Object("GetStatsResponse")
+	is.NoErr(err)
+	is.Equal(object.Fields[0].Type.TypeName, "map[string]int")
+	params = map[string]interface{}{"ftype": object.Fields[0].Type}
+	s, err = Render(`<% let ftype = params["ftype"] %><%= ts_type(ftype) %> <%= go_type(ftype) %> <%= dart_type(ftype) %> <%= python_type(ftype) %> <%= rust_type(ftype) %> <%= csharp_type(ftype) %>`, def, params)
+	is.NoErr(err)
+	is.Equal(s, "Record<string, number> map[string]int Map<String, int> Dict[str, int] HashMap<String, i64> Dictionary<string, long>")
+}
+
+func TestTSType(t *testing.T) {
+	is := is.New(t)
+	is.Equal(tsType(parser.FieldType{TSType: "string"}), template.HTML("string"))
+	is.Equal(tsType(parser.FieldType{TSType: "Greeting", ObjectName: "*Greeting"}), template.HTML("Greeting | null"))
+	is.Equal(tsType(parser.FieldType{TSType: "number", Multiple: true}), template.HTML("number[]"))
+}